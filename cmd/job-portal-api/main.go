@@ -2,20 +2,26 @@ package main
 
 import (
 	"context"
+	"crypto/rsa"
+	"errors"
 	"fmt"
 	"job-portal-api/config"
 	"job-portal-api/internal/authentication"
 	"job-portal-api/internal/cache"
 	"job-portal-api/internal/database"
 	"job-portal-api/internal/handler"
+	"job-portal-api/internal/notifier"
 	"job-portal-api/internal/repository"
+	"job-portal-api/internal/scheduler"
 	"job-portal-api/internal/service"
+	"job-portal-api/internal/storage"
+	"job-portal-api/internal/textextract"
 	"net/http"
 	"os"
 	"os/signal"
 	"time"
 
-	"github.com/golang-jwt/jwt"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/rs/zerolog/log"
 )
 
@@ -36,36 +42,18 @@ func StartApp() error {
 	//initializing authentication support
 	log.Info().Msg("main started : initializing with the authentication support")
 
-	//reading private key file
-	privatePemFile, err := os.ReadFile(`private.pem`)
-	if err != nil {
-		log.Info().Msg("Error in reading private Key file")
-		return fmt.Errorf("error in reading private key file : %w", err)
-	}
-
-	//parsing private pem filr content to rsa private key
-	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privatePemFile)
+	signingKID, privateKey, publicKeys, err := loadSigningKeys(cfg.AuthConfig)
 	if err != nil {
-		log.Info().Msg("Error in reading private Key")
-		return fmt.Errorf("error in parsing private key : %w", err)
-	}
-
-	//reading public key file
-	publicPemFile, err := os.ReadFile(`pubkey.pem`)
-	if err != nil {
-		log.Info().Msg("Error in reading public Key filer")
-		return fmt.Errorf("error in reading public key file : %w", err)
-	}
-
-	//parsing public pem file content to rsa public key
-	publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicPemFile)
-	if err != nil {
-		log.Info().Msg("error in parsing public key")
-		return fmt.Errorf("error in paring public key")
+		log.Info().Msg("error in reading signing keys")
+		return err
 	}
 
 	//callig factory function to get authentication
-	auth, err := authentication.NewAuth(privateKey, publicKey)
+	auth, err := authentication.NewAuth(signingKID, privateKey, publicKeys, authentication.Validation{
+		Issuer:   cfg.AuthConfig.Issuer,
+		Audience: cfg.AuthConfig.Audience,
+		Leeway:   cfg.AuthConfig.Leeway,
+	})
 	if err != nil {
 		log.Info().Msg("error in auth function ")
 		return fmt.Errorf("error in auth function : %w", err)
@@ -99,16 +87,47 @@ func StartApp() error {
 		return err
 	}
 
-	userService, err := service.NewUserService(userRepo, auth)
+	//jobs created before search existed have no search document yet
+	err = jobRepo.RebuildJobSearch()
 	if err != nil {
-		log.Info().Msg("error while initializing user service")
-		return fmt.Errorf("error while initializing uservservice : %w", err)
+		log.Info().Msg("error while rebuilding job search")
+		return err
 	}
 
-	companyService, err := service.NewCompanyService(companyRepo)
+	memberRepo, err := repository.NewMemberRepo(db)
 	if err != nil {
-		log.Info().Msg("error while initializing company service")
-		return fmt.Errorf("error while initializing company service : %w", err)
+		log.Info().Msg("error while initializing the member repository")
+		return err
+	}
+
+	applicationRepo, err := repository.NewApplicationRepo(db)
+	if err != nil {
+		log.Info().Msg("error while initializing the application repository")
+		return err
+	}
+
+	taxonomyRepo, err := repository.NewTaxonomyRepo(db)
+	if err != nil {
+		log.Info().Msg("error while initializing the taxonomy repository")
+		return err
+	}
+
+	candidateRepo, err := repository.NewCandidateRepo(db)
+	if err != nil {
+		log.Info().Msg("error while initializing the candidate repository")
+		return err
+	}
+
+	documentRepo, err := repository.NewDocumentRepo(db)
+	if err != nil {
+		log.Info().Msg("error while initializing the document repository")
+		return err
+	}
+
+	housekeepingRepo, err := repository.NewHousekeepingRepo(db)
+	if err != nil {
+		log.Info().Msg("error while initializing the housekeeping repository")
+		return err
 	}
 
 	redis := database.ConnectToRedis()
@@ -119,19 +138,126 @@ func StartApp() error {
 		return fmt.Errorf("error while initializing redis service : %w", err)
 	}
 
-	jobService, err := service.NewJobService(jobRepo, rdb)
+	companyService, err := service.NewCompanyService(companyRepo, memberRepo, userRepo, rdb)
+	if err != nil {
+		log.Info().Msg("error while initializing company service")
+		return fmt.Errorf("error while initializing company service : %w", err)
+	}
+
+	sessions, err := cache.NewRedisSessionStore(redis)
+	if err != nil {
+		log.Info().Msg("error while initializing session store")
+		return fmt.Errorf("error while initializing session store : %w", err)
+	}
+
+	notify, err := newNotifier(cfg.NotifierConfig)
+	if err != nil {
+		log.Info().Msg("error while initializing notifier")
+		return fmt.Errorf("error while initializing notifier : %w", err)
+	}
+
+	userService, err := service.NewUserService(userRepo, auth, sessions, notify, cfg.AuthConfig.AccessTokenTTL, cfg.AuthConfig.RefreshTokenTTL,
+		cfg.AuthConfig.PasswordResetTTL, cfg.AuthConfig.PasswordResetURL, cfg.AuthConfig.VerificationTTL, cfg.AuthConfig.VerificationURL,
+		cfg.AuthConfig.ResendInterval)
+	if err != nil {
+		log.Info().Msg("error while initializing user service")
+		return fmt.Errorf("error while initializing uservservice : %w", err)
+	}
+
+	//the first admin cannot be made through the api, the configured user is promoted instead
+	if cfg.AuthConfig.AdminEmail != "" {
+		err = userService.PromoteAdmin(context.Background(), cfg.AuthConfig.AdminEmail)
+		if errors.Is(err, service.ErrUserNotFound) {
+			log.Warn().Str("email", cfg.AuthConfig.AdminEmail).Msg("admin user has not signed up yet, it is promoted on the next start")
+		} else if err != nil {
+			log.Info().Msg("error while promoting the admin user")
+			return fmt.Errorf("error while promoting the admin user : %w", err)
+		}
+	}
+
+	jobService, err := service.NewJobService(jobRepo, memberRepo, applicationRepo, taxonomyRepo, companyRepo, rdb)
 	if err != nil {
 		log.Info().Msg("error while initializing job service")
 		return fmt.Errorf("error while initializing job service : %w", err)
 	}
 
+	applicationService, err := service.NewApplicationService(applicationRepo, jobRepo, memberRepo)
+	if err != nil {
+		log.Info().Msg("error while initializing application service")
+		return fmt.Errorf("error while initializing application service : %w", err)
+	}
+
+	taxonomyService, err := service.NewTaxonomyService(taxonomyRepo)
+	if err != nil {
+		log.Info().Msg("error while initializing taxonomy service")
+		return fmt.Errorf("error while initializing taxonomy service : %w", err)
+	}
+
+	candidateService, err := service.NewCandidateService(candidateRepo, jobRepo, applicationRepo, taxonomyRepo, rdb)
+	if err != nil {
+		log.Info().Msg("error while initializing candidate service")
+		return fmt.Errorf("error while initializing candidate service : %w", err)
+	}
+
+	documentStorage, err := storage.NewLocalStorage(cfg.StorageConfig.Dir)
+	if err != nil {
+		log.Info().Msg("error while initializing document storage")
+		return fmt.Errorf("error while initializing document storage : %w", err)
+	}
+
+	if cfg.StorageConfig.LinkSecret == "" {
+		log.Info().Msg("STORAGE_LINK_SECRET is not set")
+		return errors.New("STORAGE_LINK_SECRET must be set to sign document download links")
+	}
+
+	linkSigner, err := storage.NewSigner(cfg.StorageConfig.LinkSecret, cfg.StorageConfig.LinkTTL)
+	if err != nil {
+		log.Info().Msg("error while initializing download link signer")
+		return fmt.Errorf("error while initializing download link signer : %w", err)
+	}
+
+	documentService, err := service.NewDocumentService(documentRepo, applicationRepo, jobRepo, memberRepo, candidateRepo, taxonomyRepo,
+		documentStorage, linkSigner, textextract.NewExtractor(), cfg.StorageConfig.MaxUploadSize)
+	if err != nil {
+		log.Info().Msg("error while initializing document service")
+		return fmt.Errorf("error while initializing document service : %w", err)
+	}
+
+	housekeepingService, err := service.NewHousekeepingService(housekeepingRepo, rdb, documentStorage)
+	if err != nil {
+		log.Info().Msg("error while initializing housekeeping service")
+		return fmt.Errorf("error while initializing housekeeping service : %w", err)
+	}
+
+	//every replica runs the scheduler, the redis lock makes sure each task runs on one of them at a time
+	hostname, err := os.Hostname()
+	if err != nil {
+		log.Info().Msg("error while reading hostname")
+		return fmt.Errorf("error while reading hostname : %w", err)
+	}
+
+	locker, err := cache.NewRedisLocker(redis, fmt.Sprintf("%s-%d", hostname, os.Getpid()))
+	if err != nil {
+		log.Info().Msg("error while initializing redis locker")
+		return fmt.Errorf("error while initializing redis locker : %w", err)
+	}
+
+	tasks, err := scheduler.NewScheduler(locker, scheduler.HousekeepingTasks(housekeepingService, cfg.SchedulerConfig)...)
+	if err != nil {
+		log.Info().Msg("error while initializing scheduler")
+		return fmt.Errorf("error while initializing scheduler : %w", err)
+	}
+
+	tasks.Start()
+	log.Info().Msg("main started : scheduler is running")
+
 	//initilazing http server
 	api := http.Server{
 		Addr:         ":8080",
 		ReadTimeout:  8000 * time.Second,
 		WriteTimeout: 800 * time.Second,
 		IdleTimeout:  800 * time.Second,
-		Handler:      handler.SetupApi(auth, userService, companyService, jobService),
+		Handler:      handler.SetupApi(auth, sessions, cfg.AuthConfig.RequireVerified, userService, companyService, jobService, applicationService, taxonomyService, candidateService, documentService, cfg.StorageConfig.MaxUploadSize),
 	}
 
 	serverErrors := make(chan error, 1)
@@ -147,6 +273,11 @@ func StartApp() error {
 
 	select {
 	case err := <-serverErrors:
+		stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if stopErr := tasks.Stop(stopCtx); stopErr != nil {
+			log.Error().Err(stopErr).Msg("could not stop scheduler gracefully")
+		}
 		return fmt.Errorf("server error : %w", err)
 
 	case sig := <-shutdown:
@@ -154,7 +285,18 @@ func StartApp() error {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		err := api.Shutdown(ctx)
+		err := tasks.Stop(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("could not stop scheduler gracefully")
+		}
+
+		err = api.Shutdown(ctx)
+
+		//password reset mails are sent after the answer, they are given the rest of the shutdown time
+		if waitErr := userService.WaitBackground(ctx); waitErr != nil {
+			log.Error().Err(waitErr).Msg("could not finish sending background mails")
+		}
+
 		if err != nil {
 			err := api.Close()
 			return fmt.Errorf("could not stop server gracefully : %w", err)
@@ -165,3 +307,63 @@ func StartApp() error {
 	return nil
 
 }
+
+// newNotifier sends mails through the configured smtp server, without one the messages are only logged
+func newNotifier(cfg config.NotifierConfig) (notifier.Notifier, error) {
+	if cfg.SMTPAddr == "" {
+		log.Warn().Msg("no smtp server configured, notifications are only logged")
+		return notifier.NewLogNotifier(), nil
+	}
+	return notifier.NewSMTPNotifier(cfg.SMTPAddr, cfg.SMTPFrom, cfg.SMTPUsername, cfg.SMTPPassword)
+}
+
+// loadSigningKeys reads every key of the key directory, or the single private.pem and pubkey.pem pair
+// when no directory is configured. The kid of the pair is its thumbprint unless one is configured
+func loadSigningKeys(cfg config.AuthConfig) (string, *rsa.PrivateKey, map[string]*rsa.PublicKey, error) {
+
+	if cfg.KeysDir != "" {
+		privateKey, publicKeys, err := authentication.LoadKeyDir(cfg.KeysDir, cfg.SigningKeyID)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("error in loading key directory : %w", err)
+		}
+		return cfg.SigningKeyID, privateKey, publicKeys, nil
+	}
+
+	//reading private key file
+	privatePemFile, err := os.ReadFile(`private.pem`)
+	if err != nil {
+		log.Info().Msg("Error in reading private Key file")
+		return "", nil, nil, fmt.Errorf("error in reading private key file : %w", err)
+	}
+
+	//parsing private pem filr content to rsa private key
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privatePemFile)
+	if err != nil {
+		log.Info().Msg("Error in reading private Key")
+		return "", nil, nil, fmt.Errorf("error in parsing private key : %w", err)
+	}
+
+	//reading public key file
+	publicPemFile, err := os.ReadFile(`pubkey.pem`)
+	if err != nil {
+		log.Info().Msg("Error in reading public Key filer")
+		return "", nil, nil, fmt.Errorf("error in reading public key file : %w", err)
+	}
+
+	//parsing public pem file content to rsa public key
+	publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicPemFile)
+	if err != nil {
+		log.Info().Msg("error in parsing public key")
+		return "", nil, nil, fmt.Errorf("error in paring public key")
+	}
+
+	kid := cfg.SigningKeyID
+	if kid == "" {
+		kid, err = authentication.Thumbprint(publicKey)
+		if err != nil {
+			return "", nil, nil, err
+		}
+	}
+
+	return kid, privateKey, map[string]*rsa.PublicKey{kid: publicKey}, nil
+}
@@ -2,13 +2,19 @@ package config
 
 import (
 	"log"
+	"time"
 
 	env "github.com/Netflix/go-env"
 )
 
 var cfg Config
 
+// unmarshaling stops at the first missing required value so the configs having defaults come first
 type Config struct {
+	SchedulerConfig
+	StorageConfig
+	AuthConfig
+	NotifierConfig
 	AppConfig
 }
 
@@ -16,6 +22,48 @@ type AppConfig struct {
 	Port int `env:"APP_PORT,required=true"`
 }
 
+// SchedulerConfig sets how often each background task runs and how long deleted rows are kept
+type SchedulerConfig struct {
+	ExpireJobsInterval time.Duration `env:"SCHEDULER_EXPIRE_JOBS_INTERVAL,default=1m"`
+	PurgeInterval      time.Duration `env:"SCHEDULER_PURGE_INTERVAL,default=24h"`
+	PurgeRetention     time.Duration `env:"SCHEDULER_PURGE_RETENTION,default=720h"`
+	JobStatsInterval   time.Duration `env:"SCHEDULER_JOB_STATS_INTERVAL,default=10m"`
+}
+
+// StorageConfig sets where uploaded documents are kept, how large they can be and how long download links last
+type StorageConfig struct {
+	Dir           string        `env:"STORAGE_DIR,default=uploads"`
+	MaxUploadSize int64         `env:"STORAGE_MAX_UPLOAD_SIZE,default=5242880"`
+	LinkSecret    string        `env:"STORAGE_LINK_SECRET" json:"-"` // required, signs the document download links
+	LinkTTL       time.Duration `env:"STORAGE_LINK_TTL,default=15m"`
+}
+
+// AuthConfig sets up tokens, sessions and the links mailed to users
+type AuthConfig struct {
+	AccessTokenTTL   time.Duration `env:"AUTH_ACCESS_TOKEN_TTL,default=15m"`
+	RefreshTokenTTL  time.Duration `env:"AUTH_REFRESH_TOKEN_TTL,default=720h"`
+	KeysDir          string        `env:"AUTH_KEYS_DIR"` // empty uses the single private.pem and pubkey.pem pair
+	SigningKeyID     string        `env:"AUTH_SIGNING_KEY_ID"`
+	Issuer           string        `env:"AUTH_ISSUER,default=job portal project"`
+	Audience         string        `env:"AUTH_AUDIENCE,default=users"`
+	Leeway           time.Duration `env:"AUTH_LEEWAY,default=30s"` // allows for clock skew between servers
+	PasswordResetTTL time.Duration `env:"AUTH_PASSWORD_RESET_TTL,default=1h"`
+	PasswordResetURL string        `env:"AUTH_PASSWORD_RESET_URL,default=http://localhost:8080/reset-password"`
+	VerificationTTL  time.Duration `env:"AUTH_VERIFICATION_TTL,default=24h"`
+	VerificationURL  string        `env:"AUTH_VERIFICATION_URL,default=http://localhost:8080/verify-email"`
+	ResendInterval   time.Duration `env:"AUTH_VERIFICATION_RESEND_INTERVAL,default=1m"` // throttles verification and password reset emails
+	RequireVerified  bool          `env:"AUTH_REQUIRE_VERIFIED_EMAIL,default=false"`    // existing accounts start as pending
+	AdminEmail       string        `env:"AUTH_ADMIN_EMAIL"`                             // this user is made an admin at startup, once signed up
+}
+
+// NotifierConfig sets the smtp server mails are sent through, without an address they are only logged
+type NotifierConfig struct {
+	SMTPAddr     string `env:"SMTP_ADDR"`
+	SMTPFrom     string `env:"SMTP_FROM"`
+	SMTPUsername string `env:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD" json:"-"`
+}
+
 func init() {
 
 	_, err := env.UnmarshalFromEnviron(&cfg)
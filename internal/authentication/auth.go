@@ -3,6 +3,7 @@ package authentication
 import (
 	"crypto/rsa"
 	"errors"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -11,27 +12,68 @@ type Key int
 
 const AuthKey Key = 1
 
-// auth stuct with fields private and public key
+// auth stuct with the signing key and the accepted public keys, by kid
 type Auth struct {
+	signingKID string
 	privateKey *rsa.PrivateKey
-	publicKey  *rsa.PublicKey
+	publicKeys map[string]*rsa.PublicKey
+	validation Validation
 }
 
-//go:generate mockgen -source=auth.go -destination=auth_mock.go -package=service
+// Validation is the issuer and audience every token carries and the leeway for clock skew
+type Validation struct {
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+}
+
+// Claims are the registered claims plus the role, email status and session of the user
+type Claims struct {
+	jwt.RegisteredClaims
+	Role          string `json:"role"`
+	EmailVerified bool   `json:"email_verified"` // as of when the token was issued
+	SessionID     string `json:"sid,omitempty"`
+}
+
+//go:generate mockgen -source=auth.go -destination=auth_mock.go -package=authentication
 // authentication interface to generate and validat etoken
 type Authenticaton interface {
-	GenerateToken(claims jwt.RegisteredClaims) (string, error)
-	ValidateToken(token string) (jwt.RegisteredClaims, error)
+	GenerateToken(claims Claims) (string, error)
+	ValidateToken(token string) (Claims, error)
+	JWKS() JWKS
 }
 
-// fator function taht returns authentication having struct
-func NewAuth(privateKey *rsa.PrivateKey, publicKey *rsa.PublicKey) (Authenticaton, error) {
-	if privateKey == nil && publicKey == nil {
-		return nil, errors.New("public and private are nil")
+// fator function taht returns authentication signing with the key under signingKID
+func NewAuth(signingKID string, privateKey *rsa.PrivateKey, publicKeys map[string]*rsa.PublicKey, validation Validation) (Authenticaton, error) {
+	if privateKey == nil {
+		return nil, errors.New("private key is nil")
+	}
+	if signingKID == "" {
+		return nil, errors.New("signing key id is empty")
+	}
+	if validation.Issuer == "" || validation.Audience == "" {
+		return nil, errors.New("token issuer and audience are required")
+	}
+	if validation.Leeway < 0 {
+		return nil, errors.New("token leeway is negative")
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(publicKeys)+1)
+	for kid, key := range publicKeys {
+		if key == nil {
+			return nil, errors.New("public key " + kid + " is nil")
+		}
+		keys[kid] = key
+	}
+	if key, ok := keys[signingKID]; ok && !key.Equal(&privateKey.PublicKey) {
+		return nil, errors.New("public key " + signingKID + " does not belong to the signing key")
 	}
+	keys[signingKID] = &privateKey.PublicKey
 
 	return &Auth{
+		signingKID: signingKID,
 		privateKey: privateKey,
-		publicKey:  publicKey,
+		publicKeys: keys,
+		validation: validation,
 	}, nil
 }
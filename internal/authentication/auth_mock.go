@@ -3,15 +3,14 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=auth.go -destination=auth_mock.go -package=service
+//	mockgen -source=auth.go -destination=auth_mock.go -package=authentication
 //
-// Package service is a generated GoMock package.
+// Package authentication is a generated GoMock package.
 package authentication
 
 import (
 	reflect "reflect"
 
-	jwt "github.com/golang-jwt/jwt/v5"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -26,7 +25,6 @@ type MockAuthenticatonMockRecorder struct {
 	mock *MockAuthenticaton
 }
 
-
 // NewMockAuthenticaton creates a new mock instance.
 func NewMockAuthenticaton(ctrl *gomock.Controller) *MockAuthenticaton {
 	mock := &MockAuthenticaton{ctrl: ctrl}
@@ -40,7 +38,7 @@ func (m *MockAuthenticaton) EXPECT() *MockAuthenticatonMockRecorder {
 }
 
 // GenerateToken mocks base method.
-func (m *MockAuthenticaton) GenerateToken(claims jwt.RegisteredClaims) (string, error) {
+func (m *MockAuthenticaton) GenerateToken(claims Claims) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GenerateToken", claims)
 	ret0, _ := ret[0].(string)
@@ -54,11 +52,25 @@ func (mr *MockAuthenticatonMockRecorder) GenerateToken(claims any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockAuthenticaton)(nil).GenerateToken), claims)
 }
 
+// JWKS mocks base method.
+func (m *MockAuthenticaton) JWKS() JWKS {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS")
+	ret0, _ := ret[0].(JWKS)
+	return ret0
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockAuthenticatonMockRecorder) JWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockAuthenticaton)(nil).JWKS))
+}
+
 // ValidateToken mocks base method.
-func (m *MockAuthenticaton) ValidateToken(token string) (jwt.RegisteredClaims, error) {
+func (m *MockAuthenticaton) ValidateToken(token string) (Claims, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ValidateToken", token)
-	ret0, _ := ret[0].(jwt.RegisteredClaims)
+	ret0, _ := ret[0].(Claims)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -0,0 +1,196 @@
+package authentication
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+var testValidation = Validation{Issuer: "job portal project", Audience: "users", Leeway: time.Minute}
+
+func testClaims() Claims {
+	return Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "1",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        "jti",
+		},
+		Role: "candidate",
+	}
+}
+
+func TestAuth_ValidateToken(t *testing.T) {
+	oldKey := newKey(t)
+	activeKey := newKey(t)
+
+	oldAuth, _ := NewAuth("old", oldKey, nil, testValidation)
+	rotated, err := NewAuth("new", activeKey, map[string]*rsa.PublicKey{"old": &oldKey.PublicKey}, testValidation)
+	if err != nil {
+		t.Fatalf("NewAuth() error = %v", err)
+	}
+
+	oldToken, _ := oldAuth.GenerateToken(testClaims())
+	newToken, _ := rotated.GenerateToken(testClaims())
+
+	unknown, _ := NewAuth("other", activeKey, nil, testValidation)
+	unknownToken, _ := unknown.GenerateToken(testClaims())
+
+	noKid := jwt.NewWithClaims(jwt.SigningMethodRS256, testClaims())
+	noKidToken, _ := noKid.SignedString(activeKey)
+
+	//a token signed with the public key as an hmac secret must not be accepted
+	publicDER, _ := x509.MarshalPKIXPublicKey(&activeKey.PublicKey)
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	hmacToken.Header["kid"] = "new"
+	hmacSigned, _ := hmacToken.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "signed with the active key", token: newToken},
+		{name: "signed with a rotated out key", token: oldToken},
+		{name: "unknown key id", token: unknownToken, wantErr: true},
+		{name: "missing key id", token: noKidToken, wantErr: true},
+		{name: "other algorithm", token: hmacSigned, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rotated.ValidateToken(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Auth.ValidateToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (got.Subject != "1" || got.Role != "candidate" || got.Issuer != "job portal project") {
+				t.Errorf("Auth.ValidateToken() = %v", got)
+			}
+		})
+	}
+}
+
+func TestAuth_ValidateToken_Claims(t *testing.T) {
+	key := newKey(t)
+	auth, _ := NewAuth("a", key, nil, testValidation)
+
+	//signs the claims as they are, without the issuer and audience GenerateToken stamps
+	sign := func(change func(c *Claims)) string {
+		claims := testClaims()
+		claims.Issuer = testValidation.Issuer
+		claims.Audience = jwt.ClaimStrings{testValidation.Audience}
+		change(&claims)
+		tkn := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		tkn.Header["kid"] = "a"
+		token, _ := tkn.SignedString(key)
+		return token
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "valid", token: sign(func(c *Claims) {})},
+		{
+			name:    "other issuer",
+			token:   sign(func(c *Claims) { c.Issuer = "someone else" }),
+			wantErr: true,
+		},
+		{
+			name:    "other audience",
+			token:   sign(func(c *Claims) { c.Audience = jwt.ClaimStrings{"admins"} }),
+			wantErr: true,
+		},
+		{
+			name:    "no expiry",
+			token:   sign(func(c *Claims) { c.ExpiresAt = nil }),
+			wantErr: true,
+		},
+		{
+			name:  "expired within the leeway",
+			token: sign(func(c *Claims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-30 * time.Second)) }),
+		},
+		{
+			name:    "expired beyond the leeway",
+			token:   sign(func(c *Claims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-2 * time.Minute)) }),
+			wantErr: true,
+		},
+		{
+			name:    "issued in the future",
+			token:   sign(func(c *Claims) { c.IssuedAt = jwt.NewNumericDate(time.Now().Add(5 * time.Minute)) }),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := auth.ValidateToken(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Auth.ValidateToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewAuth(t *testing.T) {
+	key := newKey(t)
+	other := newKey(t)
+
+	_, err := NewAuth("a", key, map[string]*rsa.PublicKey{"a": &other.PublicKey}, testValidation)
+	if err == nil {
+		t.Errorf("NewAuth() accepted a public key which does not belong to the signing key")
+	}
+
+	auth, err := NewAuth("b", key, map[string]*rsa.PublicKey{"a": &other.PublicKey}, testValidation)
+	if err != nil {
+		t.Fatalf("NewAuth() error = %v", err)
+	}
+
+	_, err = NewAuth("b", key, nil, Validation{Issuer: "job portal project"})
+	if err == nil {
+		t.Errorf("NewAuth() accepted a validation without an audience")
+	}
+
+	set := auth.JWKS()
+	if len(set.Keys) != 2 || set.Keys[0].Kid != "a" || set.Keys[1].Kid != "b" || set.Keys[1].Alg != "RS256" || set.Keys[1].E != "AQAB" {
+		t.Errorf("Auth.JWKS() = %v", set)
+	}
+}
+
+func TestLoadKeyDir(t *testing.T) {
+	dir := t.TempDir()
+	active := newKey(t)
+	retired := newKey(t)
+
+	privateDER, _ := x509.MarshalPKCS8PrivateKey(active)
+	os.WriteFile(filepath.Join(dir, "2024-06.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0o600)
+	publicDER, _ := x509.MarshalPKIXPublicKey(&retired.PublicKey)
+	os.WriteFile(filepath.Join(dir, "2024-01.pub.pem"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0o600)
+
+	privateKey, publicKeys, err := LoadKeyDir(dir, "2024-06")
+	if err != nil {
+		t.Fatalf("LoadKeyDir() error = %v", err)
+	}
+	if !privateKey.Equal(active) || len(publicKeys) != 2 || !publicKeys["2024-01"].Equal(&retired.PublicKey) {
+		t.Errorf("LoadKeyDir() = %v, %v", privateKey, publicKeys)
+	}
+
+	_, _, err = LoadKeyDir(dir, "2024-01")
+	if err == nil {
+		t.Errorf("LoadKeyDir() accepted an active key without a private key")
+	}
+}
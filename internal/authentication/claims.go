@@ -0,0 +1,27 @@
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// WithClaims returns a copy of the context carrying the claims of a validated token
+func WithClaims(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, AuthKey, claims)
+}
+
+// ClaimsFromContext returns the claims the authentication middleware put in the context
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(AuthKey).(Claims)
+	return claims, ok
+}
+
+// UserID returns the id of the user the token was issued to, which is carried as the subject
+func (c Claims) UserID() (uint, error) {
+	uID, err := strconv.ParseUint(c.Subject, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid subject in token : %w", err)
+	}
+	return uint(uID), nil
+}
@@ -0,0 +1,46 @@
+package authentication
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestClaimsFromContext(t *testing.T) {
+	_, ok := ClaimsFromContext(context.Background())
+	if ok {
+		t.Errorf("ClaimsFromContext() found claims in an empty context")
+	}
+
+	want := Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "7"}, Role: "employer"}
+	got, ok := ClaimsFromContext(WithClaims(context.Background(), want))
+	if !ok || got.Subject != want.Subject || got.Role != want.Role {
+		t.Errorf("ClaimsFromContext() = %v, %v, want %v", got, ok, want)
+	}
+}
+
+func TestClaims_UserID(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject string
+		want    uint
+		wantErr bool
+	}{
+		{name: "numeric subject", subject: "42", want: 42},
+		{name: "empty subject", subject: "", wantErr: true},
+		{name: "non numeric subject", subject: "abc", wantErr: true},
+		{name: "negative subject", subject: "-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: tt.subject}}.UserID()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Claims.UserID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Claims.UserID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
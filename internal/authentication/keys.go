@@ -0,0 +1,100 @@
+package authentication
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWK is the public part of a signing key as published in the key set
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JWKS is the json web key set other services fetch to verify our tokens
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// LoadKeyDir reads the keys of a directory, <kid>.pem holds a private key and <kid>.pub.pem the public
+// key of a retired key kept only to verify the tokens it signed. The key with the active kid signs new tokens
+func LoadKeyDir(dir string, activeKID string) (*rsa.PrivateKey, map[string]*rsa.PublicKey, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var active *rsa.PrivateKey
+	publicKeys := make(map[string]*rsa.PublicKey)
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error in reading key file %s : %w", file, err)
+		}
+
+		name := filepath.Base(file)
+		if kid, ok := strings.CutSuffix(name, ".pub.pem"); ok {
+			publicKey, err := jwt.ParseRSAPublicKeyFromPEM(data)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error in parsing public key %s : %w", file, err)
+			}
+			publicKeys[kid] = publicKey
+			continue
+		}
+
+		kid := strings.TrimSuffix(name, ".pem")
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error in parsing private key %s : %w", file, err)
+		}
+		publicKeys[kid] = &privateKey.PublicKey
+		if kid == activeKID {
+			active = privateKey
+		}
+	}
+
+	if active == nil {
+		return nil, nil, fmt.Errorf("no private key found for the active key id %q", activeKID)
+	}
+
+	return active, publicKeys, nil
+}
+
+// Thumbprint returns the RFC 7638 thumbprint of the key, it is used as the kid of a key which has no name
+func Thumbprint(publicKey *rsa.PublicKey) (string, error) {
+	if publicKey == nil {
+		return "", errors.New("public key is nil")
+	}
+
+	//the members have to be in lexicographic order, which is what marshaling a map gives
+	data, err := json.Marshal(map[string]string{
+		"e":   encodeExponent(publicKey.E),
+		"kty": "RSA",
+		"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+func encodeExponent(e int) string {
+	return base64.RawURLEncoding.EncodeToString(big.NewInt(int64(e)).Bytes())
+}
@@ -1,17 +1,23 @@
 package authentication
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/rs/zerolog/log"
 )
 
-// func to generate token
-func (a *Auth) GenerateToken(claims jwt.RegisteredClaims) (string, error) {
-	//create new token
+// func to generate token stamped with our issuer and audience
+func (a *Auth) GenerateToken(claims Claims) (string, error) {
+	claims.Issuer = a.validation.Issuer
+	claims.Audience = jwt.ClaimStrings{a.validation.Audience}
+
+	//create new token, the kid tells the verifier which key signed it
 	tkn := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	tkn.Header["kid"] = a.signingKID
 
 	//signing token with private key
 	token, err := tkn.SignedString(a.privateKey)
@@ -23,25 +29,62 @@ func (a *Auth) GenerateToken(claims jwt.RegisteredClaims) (string, error) {
 }
 
 // func to validate the given token
-func (a *Auth) ValidateToken(token string) (jwt.RegisteredClaims, error) {
+func (a *Auth) ValidateToken(token string) (Claims, error) {
 
-	var rc jwt.RegisteredClaims
+	var rc Claims
 
-	//parse the token with registered claims
+	//parse the token with registered claims, only RS256 and the key under its kid are accepted
 	tkn, err := jwt.ParseWithClaims(token, &rc, func(t *jwt.Token) (interface{}, error) {
-		return a.publicKey, nil
-	})
+		kid, ok := t.Header["kid"].(string)
+		if !ok {
+			return nil, errors.New("token has no key id")
+		}
+		key, ok := a.publicKeys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		return key, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(a.validation.Issuer),
+		jwt.WithAudience(a.validation.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(a.validation.Leeway),
+	)
 
 	if err != nil {
 		log.Info().Msg("error in parsing the token")
-		return jwt.RegisteredClaims{}, fmt.Errorf("error in parsing token : %w ", err)
+		return Claims{}, fmt.Errorf("error in parsing token : %w ", err)
 	}
 
 	//check if token valid or not
 	if !tkn.Valid {
 		log.Info().Msg("token invalid")
-		return jwt.RegisteredClaims{}, errors.New("invalid token")
+		return Claims{}, errors.New("invalid token")
 	}
 
 	return rc, nil
 }
+
+// JWKS returns the public keys of every key whose tokens are accepted
+func (a *Auth) JWKS() JWKS {
+	set := JWKS{Keys: make([]JWK, 0, len(a.publicKeys))}
+	for kid, key := range a.publicKeys {
+		set.Keys = append(set.Keys, JWK{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			Alg: jwt.SigningMethodRS256.Alg(),
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   encodeExponent(key.E),
+		})
+	}
+
+	//sorted so the response does not change between requests
+	sort.Slice(set.Keys, func(i, j int) bool {
+		return set.Keys[i].Kid < set.Keys[j].Kid
+	})
+
+	return set
+}
@@ -21,6 +21,7 @@ type RDBLayer struct {
 type Caching interface {
 	AddToTheCache(ctx context.Context, jID uint, jobData model.Job) error
 	GetTheCacheData(ctx context.Context, jID uint) (string, error)
+	DeleteTheCacheData(ctx context.Context, jID uint) error
 }
 
 func NewRDBLayer(rdb *redis.Client) (Caching, error) {
@@ -49,3 +50,9 @@ func (r *RDBLayer) GetTheCacheData(ctx context.Context, jID uint) (string, error
 	str, err := r.rdb.Get(ctx, jobId).Result()
 	return str, err
 }
+
+func (r *RDBLayer) DeleteTheCacheData(ctx context.Context, jID uint) error {
+	jobId := strconv.FormatUint(uint64(jID), 10)
+	err := r.rdb.Del(ctx, jobId).Err()
+	return err
+}
@@ -53,6 +53,20 @@ func (mr *MockCachingMockRecorder) AddToTheCache(ctx, jID, jobData any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToTheCache", reflect.TypeOf((*MockCaching)(nil).AddToTheCache), ctx, jID, jobData)
 }
 
+// DeleteTheCacheData mocks base method.
+func (m *MockCaching) DeleteTheCacheData(ctx context.Context, jID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTheCacheData", ctx, jID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTheCacheData indicates an expected call of DeleteTheCacheData.
+func (mr *MockCachingMockRecorder) DeleteTheCacheData(ctx, jID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTheCacheData", reflect.TypeOf((*MockCaching)(nil).DeleteTheCacheData), ctx, jID)
+}
+
 // GetTheCacheData mocks base method.
 func (m *MockCaching) GetTheCacheData(ctx context.Context, jID uint) (string, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=lock.go -destination=lock_mock.go -package=cache
+type Locker interface {
+	TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// RedisLocker hands out locks shared by every replica talking to the same redis
+type RedisLocker struct {
+	rdb   *redis.Client
+	owner string
+}
+
+// NewRedisLocker returns a locker whose locks are marked with the owner so the holder of a lock can be told apart
+func NewRedisLocker(rdb *redis.Client, owner string) (Locker, error) {
+	if rdb == nil {
+		log.Info().Msg("Redis DB cannot be nil")
+		return nil, errors.New("Redis DB cannot be nil")
+	}
+	return &RedisLocker{
+		rdb:   rdb,
+		owner: owner,
+	}, nil
+}
+
+// TryLock takes the lock for the given time when nobody holds it and reports whether it was taken,
+// the lock is never released and simply runs out
+func (r *RedisLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return r.rdb.SetNX(ctx, "lock:"+key, r.owner, ttl).Result()
+}
@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"job-portal-api/internal/model"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session does not exist")
+	ErrSessionChanged  = errors.New("session was changed by another request")
+)
+
+//go:generate mockgen -source=session.go -destination=session_mock.go -package=cache
+type SessionStore interface {
+	SaveSession(ctx context.Context, session model.Session, ttl time.Duration) error
+	GetSession(ctx context.Context, sID string) (model.Session, error)
+	ReplaceSession(ctx context.Context, session model.Session, refreshHash string, ttl time.Duration) error
+	DeleteSession(ctx context.Context, uID uint, sID string) error
+	GetUserSessions(ctx context.Context, uID uint) ([]string, error)
+	RevokeToken(ctx context.Context, jti string, ttl time.Duration) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+// RedisSessionStore keeps every session under its own key and the ids of the sessions of a user in a set
+type RedisSessionStore struct {
+	rdb *redis.Client
+}
+
+func NewRedisSessionStore(rdb *redis.Client) (SessionStore, error) {
+	if rdb == nil {
+		log.Info().Msg("Redis DB cannot be nil")
+		return nil, errors.New("Redis DB cannot be nil")
+	}
+	return &RedisSessionStore{
+		rdb: rdb,
+	}, nil
+}
+
+func sessionKey(sID string) string {
+	return "session:" + sID
+}
+
+func userSessionsKey(uID uint) string {
+	return "user_sessions:" + strconv.FormatUint(uint64(uID), 10)
+}
+
+// SaveSession stores the session for the given time, the set of sessions of the user lives as long as its newest session
+func (r *RedisSessionStore) SaveSession(ctx context.Context, session model.Session, ttl time.Duration) error {
+	val, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.rdb.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, sessionKey(session.ID), val, ttl)
+		p.SAdd(ctx, userSessionsKey(session.UserID), session.ID)
+		p.Expire(ctx, userSessionsKey(session.UserID), ttl)
+		return nil
+	})
+	return err
+}
+
+func (r *RedisSessionStore) GetSession(ctx context.Context, sID string) (model.Session, error) {
+	val, err := r.rdb.Get(ctx, sessionKey(sID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return model.Session{}, ErrSessionNotFound
+	}
+	if err != nil {
+		return model.Session{}, err
+	}
+
+	var session model.Session
+	err = json.Unmarshal(val, &session)
+	if err != nil {
+		return model.Session{}, err
+	}
+	return session, nil
+}
+
+// ReplaceSession stores the session only while the stored one still has the given refresh token hash,
+// so of two requests refreshing with the same token only one succeeds
+func (r *RedisSessionStore) ReplaceSession(ctx context.Context, session model.Session, refreshHash string, ttl time.Duration) error {
+	val, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	key := sessionKey(session.ID)
+	err = r.rdb.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := tx.Get(ctx, key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			return ErrSessionNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var stored model.Session
+		err = json.Unmarshal(current, &stored)
+		if err != nil {
+			return err
+		}
+		if stored.RefreshHash != refreshHash {
+			return ErrSessionChanged
+		}
+
+		_, err = tx.TxPipelined(ctx, func(p redis.Pipeliner) error {
+			p.Set(ctx, key, val, ttl)
+			p.Expire(ctx, userSessionsKey(session.UserID), ttl)
+			return nil
+		})
+		return err
+	}, key)
+	if errors.Is(err, redis.TxFailedErr) {
+		return ErrSessionChanged
+	}
+	return err
+}
+
+func (r *RedisSessionStore) DeleteSession(ctx context.Context, uID uint, sID string) error {
+	_, err := r.rdb.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.Del(ctx, sessionKey(sID))
+		p.SRem(ctx, userSessionsKey(uID), sID)
+		return nil
+	})
+	return err
+}
+
+// GetUserSessions returns the ids of the sessions of the user, some of them may have expired already
+func (r *RedisSessionStore) GetUserSessions(ctx context.Context, uID uint) ([]string, error) {
+	return r.rdb.SMembers(ctx, userSessionsKey(uID)).Result()
+}
+
+// RevokeToken marks the access token as revoked for the given time, which should last until the token expires
+func (r *RedisSessionStore) RevokeToken(ctx context.Context, jti string, ttl time.Duration) error {
+	return r.rdb.Set(ctx, "revoked_token:"+jti, 1, ttl).Err()
+}
+
+func (r *RedisSessionStore) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	n, err := r.rdb.Exists(ctx, "revoked_token:"+jti).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
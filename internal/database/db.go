@@ -15,7 +15,8 @@ import (
 func DatabaseConnection() (*gorm.DB, error) {
 
 	dsn := os.Getenv("DB_DSN")
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	//translated errors let the repositories tell unique constraint violations apart
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Info().Msg("error in opening database connection")
 		return nil, fmt.Errorf("error in opening database connection : %w", err)
@@ -37,7 +38,9 @@ func DatabaseConnection() (*gorm.DB, error) {
 	}
 
 	//need auto migrate
-	err = db.Migrator().AutoMigrate(&model.User{}, &model.Company{}, &model.Job{})
+	err = db.Migrator().AutoMigrate(&model.User{}, &model.Company{}, &model.Job{}, &model.CompanyMember{}, &model.Application{}, &model.ApplicationStatusHistory{},
+		&model.Location{}, &model.TechnologyStack{}, &model.Qualification{}, &model.Shift{}, &model.JobType{}, &model.JobStats{}, &model.CandidateProfile{}, &model.Document{},
+		&model.PasswordReset{}, &model.EmailVerification{})
 	if err != nil {
 		log.Error().Err(err).Msg("error in creating tables")
 		return nil, fmt.Errorf("error in creating tables : %w", err)
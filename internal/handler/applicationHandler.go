@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"job-portal-api/internal/authentication"
+	"job-portal-api/internal/middleware"
+	"job-portal-api/internal/model"
+	"job-portal-api/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog/log"
+)
+
+type ApplicationHandler interface {
+	ViewApplicationsByJobID(c *gin.Context)
+	ViewApplicationByID(c *gin.Context)
+	MoveApplication(c *gin.Context)
+	ViewApplicationHistory(c *gin.Context)
+}
+
+func NewApplicationHandler(serviceApplication service.ApplicationService) (ApplicationHandler, error) {
+	if serviceApplication == nil {
+		log.Info().Msg("application service cannot be nil")
+		return nil, errors.New("application service cannot be nil")
+	}
+
+	return &Handler{
+		serviceApplication: serviceApplication,
+	}, nil
+}
+
+func (h *Handler) ViewApplicationsByJobID(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	jID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error invalid job id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	applications, err := h.serviceApplication.ViewApplicationsByJobID(uint(jID), uID)
+	if errors.Is(err, service.ErrNotCompanyMember) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("user cannot view applications of the job")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": http.StatusText(http.StatusForbidden)})
+		return
+	}
+	if errors.Is(err, service.ErrJobNotFound) || errors.Is(err, service.ErrApplicationNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("job or application not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in fetching applications")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	c.JSON(http.StatusOK, applications)
+}
+
+func (h *Handler) ViewApplicationByID(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	aID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error invalid application id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	application, err := h.serviceApplication.ViewApplicationByID(uint(aID), uID)
+	if errors.Is(err, service.ErrNotCompanyMember) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("user cannot view the application")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": http.StatusText(http.StatusForbidden)})
+		return
+	}
+	if errors.Is(err, service.ErrJobNotFound) || errors.Is(err, service.ErrApplicationNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("job or application not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in fetching application")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	c.JSON(http.StatusOK, application)
+}
+
+func (h *Handler) MoveApplication(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	aID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error invalid application id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	var update model.ApplicationStatusUpdate
+
+	err = json.NewDecoder(c.Request.Body).Decode(&update)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in decoding")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(update)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in validating status")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	application, err := h.serviceApplication.MoveApplication(uint(aID), uID, update)
+	if errors.Is(err, service.ErrNotCompanyMember) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("user cannot move the application")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": http.StatusText(http.StatusForbidden)})
+		return
+	}
+	if errors.Is(err, service.ErrInvalidTransition) || errors.Is(err, service.ErrStatusChanged) {
+		log.Error().Err(err).Str("trace id : ", traceId).Str("status", update.Status).Msg("invalid status transition")
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrJobNotFound) || errors.Is(err, service.ErrApplicationNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("job or application not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in moving application")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	c.JSON(http.StatusOK, application)
+}
+
+func (h *Handler) ViewApplicationHistory(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	aID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error invalid application id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	history, err := h.serviceApplication.ViewApplicationHistory(uint(aID), uID)
+	if errors.Is(err, service.ErrNotCompanyMember) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("user cannot view the application")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": http.StatusText(http.StatusForbidden)})
+		return
+	}
+	if errors.Is(err, service.ErrJobNotFound) || errors.Is(err, service.ErrApplicationNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("job or application not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in fetching application history")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
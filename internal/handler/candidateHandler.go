@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"job-portal-api/internal/authentication"
+	"job-portal-api/internal/middleware"
+	"job-portal-api/internal/model"
+	"job-portal-api/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog/log"
+)
+
+type CandidateHandler interface {
+	SaveProfile(c *gin.Context)
+	ViewProfile(c *gin.Context)
+	ApplyToJob(c *gin.Context)
+	ViewMyApplications(c *gin.Context)
+	WithdrawApplication(c *gin.Context)
+}
+
+func NewCandidateHandler(serviceCandidate service.CandidateService) (CandidateHandler, error) {
+	if serviceCandidate == nil {
+		log.Info().Msg("candidate service cannot be nil")
+		return nil, errors.New("candidate service cannot be nil")
+	}
+
+	return &Handler{
+		serviceCandidate: serviceCandidate,
+	}, nil
+}
+
+// SaveProfile creates or replaces the profile of the logged in candidate
+func (h *Handler) SaveProfile(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	var profile model.NewCandidateProfile
+
+	err = json.NewDecoder(c.Request.Body).Decode(&profile)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in decoding")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(profile)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in validating profile")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	profileData, err := h.serviceCandidate.SaveProfile(uID, profile)
+	var refErr *service.InvalidReferencesError
+	if errors.As(err, &refErr) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("profile refers to invalid values")
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": refErr.Error(), "invalid": refErr.References})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in saving profile")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, profileData)
+}
+
+func (h *Handler) ViewProfile(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	profile, err := h.serviceCandidate.ViewProfile(uID)
+	if errors.Is(err, service.ErrProfileNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("profile not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in fetching profile")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// applyOutcomeStatus maps the outcome of a single application to the status code of the response
+var applyOutcomeStatus = map[string]int{
+	model.OutcomeAccepted:      http.StatusCreated,
+	model.OutcomeRejected:      http.StatusCreated,
+	model.OutcomeInvalidJob:    http.StatusNotFound,
+	model.OutcomeJobClosed:     http.StatusConflict,
+	model.OutcomeDuplicate:     http.StatusConflict,
+	model.OutcomeInternalError: http.StatusInternalServerError,
+}
+
+// ApplyToJob applies the logged in candidate to the job with their stored profile, a rejected
+// application is still created and reported along with the match
+func (h *Handler) ApplyToJob(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	jID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error invalid job id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	result, err := h.serviceCandidate.ApplyWithProfile(uint(jID), uID)
+	if errors.Is(err, service.ErrProfileNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("candidate has no profile")
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in applying to job")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	status, ok := applyOutcomeStatus[result.Outcome]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	if status >= http.StatusBadRequest {
+		log.Error().Str("trace id : ", traceId).Str("outcome", result.Outcome).Msg(result.Error)
+		c.AbortWithStatusJSON(status, gin.H{"error": result.Error})
+		return
+	}
+
+	c.JSON(status, result)
+}
+
+func (h *Handler) ViewMyApplications(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	applications, err := h.serviceCandidate.ViewMyApplications(uID)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in fetching applications")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, applications)
+}
+
+// WithdrawApplication withdraws an application of the logged in candidate
+func (h *Handler) WithdrawApplication(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	aID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error invalid application id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	application, err := h.serviceCandidate.WithdrawApplication(uint(aID), uID)
+	if errors.Is(err, service.ErrApplicationNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("application not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if errors.Is(err, service.ErrInvalidTransition) || errors.Is(err, service.ErrStatusChanged) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("application cannot be withdrawn")
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in withdrawing application")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, application)
+}
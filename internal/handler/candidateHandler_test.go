@@ -0,0 +1,306 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"job-portal-api/internal/authentication"
+	"job-portal-api/internal/middleware"
+	"job-portal-api/internal/model"
+	"job-portal-api/internal/service"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestHandler_ApplyToJob(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.CandidateService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "missing trace id",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.CandidateService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error":"Internal Server Error"}`,
+		},
+		{
+			name: "invalid user in token",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.CandidateService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "abc"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "2"})
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"error":"Unauthorized"}`,
+		},
+		{
+			name: "invalid job id",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.CandidateService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "abc"})
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Bad Request"}`,
+		},
+		{
+			name: "candidate has no profile",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.CandidateService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "2"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockCandidateService(mc)
+
+				ms.EXPECT().ApplyWithProfile(uint(2), uint(1)).Return(model.ProcessedApplication{}, service.ErrProfileNotFound)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `{"error":"candidate profile does not exist"}`,
+		},
+		{
+			name: "error in applying",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.CandidateService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "2"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockCandidateService(mc)
+
+				ms.EXPECT().ApplyWithProfile(uint(2), uint(1)).Return(model.ProcessedApplication{}, errors.New("error"))
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error":"Internal Server Error"}`,
+		},
+		{
+			name: "job closed",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.CandidateService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "2"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockCandidateService(mc)
+
+				ms.EXPECT().ApplyWithProfile(uint(2), uint(1)).Return(model.ProcessedApplication{Outcome: model.OutcomeJobClosed, Error: "job is closed for applications"}, nil)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `{"error":"job is closed for applications"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.CandidateService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "2"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockCandidateService(mc)
+
+				ms.EXPECT().ApplyWithProfile(uint(2), uint(1)).Return(model.ProcessedApplication{Outcome: model.OutcomeAccepted, ApplicationID: 3, Score: 100, Accepted: true}, nil)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse:   `{"index":0,"outcome":"accepted","applicationID":3,"application":{"name":"","age":"","jid":0,"userID":0,"job_application":{"noticePeriod":0,"location":null,"technologyStack":null,"experience":0,"qualifications":null,"shifts":null,"jobtype":null,"expectedSalary":0,"workMode":null}},"score":100,"accepted":true,"report":null}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, ms := tt.setup()
+			h := Handler{
+				serviceCandidate: ms,
+			}
+			h.ApplyToJob(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestHandler_WithdrawApplication(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.CandidateService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "missing jwt claims",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.CandidateService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"error":"Unauthorized"}`,
+		},
+		{
+			name: "invalid application id",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.CandidateService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "abc"})
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Bad Request"}`,
+		},
+		{
+			name: "application of another user",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.CandidateService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "3"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockCandidateService(mc)
+
+				ms.EXPECT().WithdrawApplication(uint(3), uint(1)).Return(model.Application{}, service.ErrApplicationNotFound)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"error":"Not Found"}`,
+		},
+		{
+			name: "application already decided",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.CandidateService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "3"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockCandidateService(mc)
+
+				ms.EXPECT().WithdrawApplication(uint(3), uint(1)).Return(model.Application{}, service.ErrInvalidTransition)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `{"error":"application cannot move to the requested status"}`,
+		},
+		{
+			name: "failure",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.CandidateService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "3"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockCandidateService(mc)
+
+				ms.EXPECT().WithdrawApplication(uint(3), uint(1)).Return(model.Application{}, errors.New("error"))
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error":"Internal Server Error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, ms := tt.setup()
+			h := Handler{
+				serviceCandidate: ms,
+			}
+			h.WithdrawApplication(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
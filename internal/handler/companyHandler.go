@@ -12,7 +12,6 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/rs/zerolog/log"
 )
 
@@ -21,6 +20,12 @@ type CompanyHandler interface {
 	AddCompany(c *gin.Context)
 	ViewCompanyByID(c *gin.Context)
 	ViewAllComapny(c *gin.Context)
+	InviteMember(c *gin.Context)
+	AcceptInvite(c *gin.Context)
+	RemoveMember(c *gin.Context)
+	ViewMembers(c *gin.Context)
+	UpdateCompany(c *gin.Context)
+	DeleteCompany(c *gin.Context)
 }
 
 func NewCompanyHandler(companyService service.ComapnyService) (CompanyHandler, error) {
@@ -44,16 +49,23 @@ func (h *Handler) AddCompany(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
 		return
 	}
-	_, ok = ctx.Value(authentication.AuthKey).(jwt.RegisteredClaims)
+	claims, ok := authentication.ClaimsFromContext(ctx)
 	if !ok {
 		log.Info().Str("trace Id : ", traceId).Msg("login not success")
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
 		return
 	}
 
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace Id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
 	var companyData model.AddCompany
 
-	err := json.NewDecoder(c.Request.Body).Decode(&companyData)
+	err = json.NewDecoder(c.Request.Body).Decode(&companyData)
 	if err != nil {
 		log.Error().Err(err).Str("trace Id : ", traceId).Msg("error in decpding")
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
@@ -69,7 +81,7 @@ func (h *Handler) AddCompany(c *gin.Context) {
 		return
 	}
 
-	company, err := h.serviceComapny.AddingCompany(companyData)
+	company, err := h.serviceComapny.AddingCompany(companyData, uID)
 	if err != nil {
 		log.Error().Err(err).Msg("error in creating company")
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
@@ -90,7 +102,7 @@ func (h *Handler) ViewCompanyByID(c *gin.Context) {
 		return
 	}
 
-	_, ok = ctx.Value(authentication.AuthKey).(jwt.RegisteredClaims)
+	_, ok = authentication.ClaimsFromContext(ctx)
 	if !ok {
 		log.Info().Str("trcae Id : ", traceId).Msg("login failed")
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
@@ -127,7 +139,7 @@ func (h *Handler) ViewAllComapny(c *gin.Context) {
 		return
 	}
 
-	_, ok = ctx.Value(authentication.AuthKey).(jwt.RegisteredClaims)
+	_, ok = authentication.ClaimsFromContext(ctx)
 	if !ok {
 		log.Info().Str("trace id : ", traceId).Msg("login first")
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
@@ -143,3 +155,331 @@ func (h *Handler) ViewAllComapny(c *gin.Context) {
 
 	c.JSON(http.StatusOK, CompanysData)
 }
+
+func (h *Handler) InviteMember(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("trace id missing")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	cID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid company id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	var invite model.InviteMember
+
+	err = json.NewDecoder(c.Request.Body).Decode(&invite)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in decoding")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(invite)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in validating invite")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	member, err := h.serviceComapny.InviteMember(uint(cID), uID, invite)
+	if errors.Is(err, service.ErrNotCompanyMember) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("user cannot invite members")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error ": http.StatusText(http.StatusForbidden)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in inviting member")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+func (h *Handler) AcceptInvite(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("trace id missing")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	cID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid company id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	member, err := h.serviceComapny.AcceptInvite(uint(cID), uID)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in accepting invite")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+func (h *Handler) RemoveMember(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("trace id missing")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	cID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid company id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	memberID, err := strconv.ParseUint(c.Param("userID"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid member id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	err = h.serviceComapny.RemoveMember(uint(cID), uID, uint(memberID))
+	if errors.Is(err, service.ErrNotCompanyMember) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("user cannot remove members")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error ": http.StatusText(http.StatusForbidden)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in removing member")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"msg": "member removed"})
+}
+
+func (h *Handler) ViewMembers(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("trace id missing")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	cID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid company id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	members, err := h.serviceComapny.ViewMembers(uint(cID), uID)
+	if errors.Is(err, service.ErrNotCompanyMember) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("user is not a member")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error ": http.StatusText(http.StatusForbidden)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in fetching members")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+func (h *Handler) UpdateCompany(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("trace id missing")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	cID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid company id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	var update model.UpdateCompany
+
+	err = json.NewDecoder(c.Request.Body).Decode(&update)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in decoding")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(update)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in validating company")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	companyData, err := h.serviceComapny.UpdateCompany(uint(cID), uID, update)
+	if errors.Is(err, service.ErrCompanyNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("company not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error ": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if errors.Is(err, service.ErrNotCompanyMember) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("user cannot update the company")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error ": http.StatusText(http.StatusForbidden)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in updating company")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, companyData)
+}
+
+func (h *Handler) DeleteCompany(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("trace id missing")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	cID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid company id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	err = h.serviceComapny.DeleteCompany(uint(cID), uID)
+	if errors.Is(err, service.ErrCompanyNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("company not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error ": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if errors.Is(err, service.ErrNotCompanyMember) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("user cannot delete the company")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error ": http.StatusText(http.StatusForbidden)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in deleting company")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"msg": "company deleted"})
+}
@@ -69,7 +69,7 @@ func TestHandler_AddCompany(t *testing.T) {
 				))
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Request = httpRequest
 
@@ -92,7 +92,7 @@ func TestHandler_AddCompany(t *testing.T) {
 				))
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Request = httpRequest
 
@@ -115,14 +115,14 @@ func TestHandler_AddCompany(t *testing.T) {
 				))
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Request = httpRequest
 
 				mc := gomock.NewController(t)
 				mcom := service.NewMockComapnyService(mc)
 
-				mcom.EXPECT().AddingCompany(gomock.Any()).Return(model.Company{}, errors.New("error"))
+				mcom.EXPECT().AddingCompany(gomock.Any(), gomock.Any()).Return(model.Company{}, errors.New("error"))
 
 				return c, rr, mcom
 			},
@@ -143,14 +143,14 @@ func TestHandler_AddCompany(t *testing.T) {
 				))
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Request = httpRequest
 
 				mc := gomock.NewController(t)
 				mcom := service.NewMockComapnyService(mc)
 
-				mcom.EXPECT().AddingCompany(gomock.Any()).Return(model.Company{}, nil)
+				mcom.EXPECT().AddingCompany(gomock.Any(), gomock.Any()).Return(model.Company{}, nil)
 
 				return c, rr, mcom
 			},
@@ -217,7 +217,7 @@ func TestHandler_ViewCompanyByID(t *testing.T) {
 				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Params = append(c.Params, gin.Param{Key: "id", Value: "abc"})
 				c.Request = httpRequest
@@ -235,7 +235,7 @@ func TestHandler_ViewCompanyByID(t *testing.T) {
 				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
 				c.Request = httpRequest
@@ -258,7 +258,7 @@ func TestHandler_ViewCompanyByID(t *testing.T) {
 				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
 				c.Request = httpRequest
@@ -333,7 +333,7 @@ func TestHandler_ViewAllComapny(t *testing.T) {
 				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Request = httpRequest
 
@@ -355,7 +355,7 @@ func TestHandler_ViewAllComapny(t *testing.T) {
 				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Request = httpRequest
 
@@ -383,3 +383,300 @@ func TestHandler_ViewAllComapny(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_InviteMember(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.ComapnyService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "missing jwt claims",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.ComapnyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"error ":"Unauthorized"}`,
+		},
+		{
+			name: "invalid company id",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.ComapnyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", strings.NewReader(`{"emailID":"abc@gmail.com","role":"recruiter"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "abc"})
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error ":"Bad Request"}`,
+		},
+		{
+			name: "error in validating",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.ComapnyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", strings.NewReader(`{"emailID":"abc@gmail.com","role":"manager"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error ":"Bad Request"}`,
+		},
+		{
+			name: "not an owner",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.ComapnyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", strings.NewReader(`{"emailID":"abc@gmail.com","role":"recruiter"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mcom := service.NewMockComapnyService(mc)
+
+				mcom.EXPECT().InviteMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.CompanyMember{}, service.ErrNotCompanyMember)
+
+				return c, rr, mcom
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error ":"Forbidden"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.ComapnyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", strings.NewReader(`{"emailID":"abc@gmail.com","role":"recruiter"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mcom := service.NewMockComapnyService(mc)
+
+				mcom.EXPECT().InviteMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.CompanyMember{}, nil)
+
+				return c, rr, mcom
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"ID":0,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"companyID":0,"userID":0,"role":"","status":"","invitedBy":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, mcom := tt.setup()
+			h := Handler{
+				serviceComapny: mcom,
+			}
+			h.InviteMember(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestHandler_RemoveMember(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.ComapnyService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "invalid member id",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.ComapnyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodDelete, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"}, gin.Param{Key: "userID", Value: "abc"})
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error ":"Bad Request"}`,
+		},
+		{
+			name: "failure",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.ComapnyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodDelete, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"}, gin.Param{Key: "userID", Value: "2"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mcom := service.NewMockComapnyService(mc)
+
+				mcom.EXPECT().RemoveMember(uint(1), uint(1), uint(2)).Return(errors.New("error"))
+
+				return c, rr, mcom
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error ":"Bad Request"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.ComapnyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodDelete, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"}, gin.Param{Key: "userID", Value: "2"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mcom := service.NewMockComapnyService(mc)
+
+				mcom.EXPECT().RemoveMember(uint(1), uint(1), uint(2)).Return(nil)
+
+				return c, rr, mcom
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"msg":"member removed"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, mcom := tt.setup()
+			h := Handler{
+				serviceComapny: mcom,
+			}
+			h.RemoveMember(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestHandler_DeleteCompany(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.ComapnyService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "company not found",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.ComapnyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodDelete, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mcom := service.NewMockComapnyService(mc)
+
+				mcom.EXPECT().DeleteCompany(uint(1), uint(1)).Return(service.ErrCompanyNotFound)
+
+				return c, rr, mcom
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"error ":"Not Found"}`,
+		},
+		{
+			name: "user is not the owner",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.ComapnyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodDelete, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mcom := service.NewMockComapnyService(mc)
+
+				mcom.EXPECT().DeleteCompany(uint(1), uint(1)).Return(service.ErrNotCompanyMember)
+
+				return c, rr, mcom
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error ":"Forbidden"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.ComapnyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodDelete, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mcom := service.NewMockComapnyService(mc)
+
+				mcom.EXPECT().DeleteCompany(uint(1), uint(1)).Return(nil)
+
+				return c, rr, mcom
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"msg":"company deleted"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, ms := tt.setup()
+			h := Handler{
+				serviceComapny: ms,
+			}
+			h.DeleteCompany(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"errors"
+	"job-portal-api/internal/authentication"
+	"job-portal-api/internal/middleware"
+	"job-portal-api/internal/model"
+	"job-portal-api/internal/service"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog/log"
+)
+
+type DocumentHandler interface {
+	UploadDocument(c *gin.Context)
+	ViewApplicationDocuments(c *gin.Context)
+	DownloadDocument(c *gin.Context)
+}
+
+// multipartOverhead leaves room in an upload for the other form fields and the part headers
+const multipartOverhead = 64 << 10
+
+func NewDocumentHandler(serviceDocument service.DocumentService, maxUploadSize int64) (DocumentHandler, error) {
+	if serviceDocument == nil {
+		log.Info().Msg("document service cannot be nil")
+		return nil, errors.New("document service cannot be nil")
+	}
+
+	return &Handler{
+		serviceDocument: serviceDocument,
+		maxUploadSize:   maxUploadSize,
+	}, nil
+}
+
+// UploadDocument stores a resume or cover letter for the application in application_id or the profile
+func (h *Handler) UploadDocument(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	//the body is capped before it is parsed so an oversized upload is not spooled to memory or disk
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize+multipartOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("document is too large")
+		c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": service.ErrDocumentTooLarge.Error()})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in reading uploaded file")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	upload := model.NewDocument{
+		Kind:     c.PostForm("kind"),
+		FileName: fileHeader.Filename,
+		Size:     fileHeader.Size,
+	}
+
+	if appID := c.PostForm("application_id"); appID != "" {
+		aID, err := strconv.ParseUint(appID, 10, 64)
+		if err != nil {
+			log.Error().Err(err).Str("trace id : ", traceId).Msg("error invalid application id")
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+			return
+		}
+		id := uint(aID)
+		upload.ApplicationID = &id
+	}
+
+	validate := validator.New()
+	err = validate.Struct(upload)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in validating document")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in opening uploaded file")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	defer file.Close()
+
+	document, err := h.serviceDocument.UploadDocument(ctx, uID, upload, file)
+	if errors.Is(err, service.ErrDocumentTooLarge) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("document is too large")
+		c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrInvalidDocument) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("document type not accepted")
+		c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrApplicationNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("application not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in uploading document")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, document)
+}
+
+func (h *Handler) ViewApplicationDocuments(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	aID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error invalid application id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	documents, err := h.serviceDocument.ViewApplicationDocuments(uint(aID), uID)
+	if errors.Is(err, service.ErrNotCompanyMember) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("user cannot view the application")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": http.StatusText(http.StatusForbidden)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in fetching documents")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, documents)
+}
+
+// DownloadDocument serves a document for a signed link, the signature takes the place of a login
+func (h *Handler) DownloadDocument(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	dID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error invalid document id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error invalid link expiry")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	document, file, err := h.serviceDocument.OpenDocument(ctx, uint(dID), expires, c.Query("signature"))
+	if errors.Is(err, service.ErrInvalidLink) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid download link")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrDocumentNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("document not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in opening document")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+	defer file.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": document.FileName})
+	c.DataFromReader(http.StatusOK, document.Size, document.ContentType, file, map[string]string{"Content-Disposition": disposition})
+}
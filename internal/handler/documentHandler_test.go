@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"job-portal-api/internal/authentication"
+	"job-portal-api/internal/middleware"
+	"job-portal-api/internal/model"
+	"job-portal-api/internal/service"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+// uploadRequest builds a multipart upload of a resume with the given content
+func uploadRequest(content []byte) *http.Request {
+	body := new(bytes.Buffer)
+	form := multipart.NewWriter(body)
+	_ = form.WriteField("kind", model.DocumentResume)
+	part, _ := form.CreateFormFile("file", "cv.pdf")
+	_, _ = part.Write(content)
+	_ = form.Close()
+
+	httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", body)
+	httpRequest.Header.Set("Content-Type", form.FormDataContentType())
+	ctx := httpRequest.Context()
+	ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+	ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+	return httpRequest.WithContext(ctx)
+}
+
+func TestHandler_UploadDocument(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.DocumentService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "body larger than the limit is not parsed",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.DocumentService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				c.Request = uploadRequest(bytes.Repeat([]byte("a"), 200<<10))
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+			expectedResponse:   `{"error":"document is larger than allowed"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.DocumentService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				c.Request = uploadRequest([]byte("%PDF-1.7"))
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockDocumentService(mc)
+				ms.EXPECT().UploadDocument(gomock.Any(), uint(1), model.NewDocument{Kind: model.DocumentResume, FileName: "cv.pdf", Size: 8}, gomock.Any()).
+					Return(model.Document{UserID: 1, Kind: model.DocumentResume, FileName: "cv.pdf", Size: 8}, nil)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, ms := tt.setup()
+			h := Handler{
+				serviceDocument: ms,
+				maxUploadSize:   100 << 10,
+			}
+			h.UploadDocument(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedResponse != "" {
+				assert.Equal(t, tt.expectedResponse, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_DownloadDocument(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.DocumentService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "missing trace id",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.DocumentService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error":"Internal Server Error"}`,
+		},
+		{
+			name: "missing expiry",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.DocumentService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com?signature=abcd", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "5"})
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Bad Request"}`,
+		},
+		{
+			name: "invalid link",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.DocumentService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com?expires=100&signature=abcd", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "5"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockDocumentService(mc)
+
+				ms.EXPECT().OpenDocument(gomock.Any(), uint(5), int64(100), "abcd").Return(model.Document{}, nil, service.ErrInvalidLink)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"download link is invalid or has expired"}`,
+		},
+		{
+			name: "failure",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.DocumentService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com?expires=100&signature=abcd", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "5"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockDocumentService(mc)
+
+				ms.EXPECT().OpenDocument(gomock.Any(), uint(5), int64(100), "abcd").Return(model.Document{}, nil, errors.New("error"))
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error":"Internal Server Error"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.DocumentService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com?expires=100&signature=abcd", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "5"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockDocumentService(mc)
+
+				document := model.Document{FileName: "cv.pdf", ContentType: "application/pdf", Size: 8}
+				ms.EXPECT().OpenDocument(gomock.Any(), uint(5), int64(100), "abcd").Return(document, io.NopCloser(strings.NewReader("%PDF-1.7")), nil)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `%PDF-1.7`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, ms := tt.setup()
+			h := Handler{
+				serviceDocument: ms,
+			}
+			h.DownloadDocument(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
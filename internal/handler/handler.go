@@ -3,7 +3,9 @@ package handler
 import (
 	"fmt"
 	"job-portal-api/internal/authentication"
+	"job-portal-api/internal/cache"
 	"job-portal-api/internal/middleware"
+	"job-portal-api/internal/model"
 	"job-portal-api/internal/service"
 	"log"
 	"net/http"
@@ -13,16 +15,24 @@ import (
 )
 
 type Handler struct {
-	serviceUser    service.UserService
-	serviceComapny service.ComapnyService
-	serviceJob     service.JobService
+	serviceUser        service.UserService
+	serviceComapny     service.ComapnyService
+	serviceJob         service.JobService
+	serviceApplication service.ApplicationService
+	serviceTaxonomy    service.TaxonomyService
+	serviceCandidate   service.CandidateService
+	serviceDocument    service.DocumentService
+	maxUploadSize      int64
 }
 
-func SetupApi(auth authentication.Authenticaton, userService service.UserService, comapnyService service.ComapnyService, jobService service.JobService) *gin.Engine {
+// SetupApi registers every route, requiring a verified email keeps users who have not verified theirs
+// from applying to and posting jobs
+func SetupApi(auth authentication.Authenticaton, sessions cache.SessionStore, requireVerifiedEmail bool, userService service.UserService, comapnyService service.ComapnyService, jobService service.JobService, applicationService service.ApplicationService, taxonomyService service.TaxonomyService,
+	candidateService service.CandidateService, documentService service.DocumentService, maxUploadSize int64) *gin.Engine {
 
 	router := gin.New()
 
-	mid, err := middleware.NewMid(auth)
+	mid, err := middleware.NewMid(auth, sessions, requireVerifiedEmail)
 	if err != nil {
 		log.Panic("middleware are not set")
 	}
@@ -42,26 +52,99 @@ func SetupApi(auth authentication.Authenticaton, userService service.UserService
 		log.Panic("job handlers are not set")
 	}
 
+	applicationHandler, err := NewApplicationHandler(applicationService)
+	if err != nil {
+		log.Panic("application handlers are not set")
+	}
+
+	taxonomyHandler, err := NewTaxonomyHandler(taxonomyService)
+	if err != nil {
+		log.Panic("taxonomy handlers are not set")
+	}
+
+	candidateHandler, err := NewCandidateHandler(candidateService)
+	if err != nil {
+		log.Panic("candidate handlers are not set")
+	}
+
+	documentHandler, err := NewDocumentHandler(documentService, maxUploadSize)
+	if err != nil {
+		log.Panic("document handlers are not set")
+	}
+
 	router.Use(mid.Log(), gin.Recovery())
 
 	router.GET("/api/check", check)
+	router.GET("/.well-known/jwks.json", jwks(auth))
 
 	router.POST("/api/signup", userHandler.Signup)
 	router.POST("/api/login", userHandler.login)
-
-	router.POST("/api/create_comapny", mid.Authentication(companyHandler.AddCompany))
+	router.POST("/api/refresh", userHandler.Refresh)
+	router.POST("/api/logout", mid.Authentication(userHandler.Logout))
+	router.POST("/api/logout/all", mid.Authentication(userHandler.LogoutEverywhere))
+	router.POST("/api/password/forgot", userHandler.ForgotPassword)
+	router.POST("/api/password/reset", userHandler.ResetPassword)
+	router.POST("/api/email/verify", userHandler.VerifyEmail)
+	router.POST("/api/email/verify/resend", mid.Authentication(userHandler.ResendVerification))
+	router.PUT("/api/users/:id/role", mid.Authentication(mid.RequireRole(userHandler.UpdateUserRole, model.RoleAdmin)))
+
+	router.POST("/api/create_comapny", mid.Authentication(mid.RequireRole(companyHandler.AddCompany, model.RoleCompanyAdmin, model.RoleAdmin)))
 	router.GET("/api/get_company/:id", mid.Authentication(companyHandler.ViewCompanyByID))
 	router.GET("/api/get_companies", mid.Authentication(companyHandler.ViewAllComapny))
-
-	router.POST("/api/addjob/companyID/:id", mid.Authentication(jobHandler.CreateJobByCompanyID))
+	router.PATCH("/api/company/:id", mid.Authentication(mid.RequireRole(companyHandler.UpdateCompany, model.RoleCompanyAdmin, model.RoleAdmin)))
+	router.DELETE("/api/company/:id", mid.Authentication(mid.RequireRole(companyHandler.DeleteCompany, model.RoleCompanyAdmin, model.RoleAdmin)))
+	router.POST("/api/company/:id/invite_member", mid.Authentication(mid.RequireRole(companyHandler.InviteMember, model.RoleCompanyAdmin, model.RoleAdmin)))
+	router.POST("/api/company/:id/accept_invite", mid.Authentication(companyHandler.AcceptInvite))
+	router.DELETE("/api/company/:id/remove_member/:userID", mid.Authentication(companyHandler.RemoveMember))
+	router.GET("/api/company/:id/members", mid.Authentication(companyHandler.ViewMembers))
+
+	router.POST("/api/addjob/companyID/:id", mid.Authentication(mid.RequireRole(mid.RequireVerifiedEmail(jobHandler.CreateJobByCompanyID), model.RoleRecruiter, model.RoleCompanyAdmin, model.RoleAdmin)))
 	router.GET("/api/get_job_by_company_id/:id", mid.Authentication(jobHandler.ViewJobByCompanyId))
 	router.GET("/api/get_job_by_job_id/:id", mid.Authentication(jobHandler.ViewJobByJobID))
 	router.GET("/api/get_jobs", mid.Authentication(jobHandler.ViewAllJobs))
-	router.GET("/api/process_application", mid.Authentication(jobHandler.ProcessJobApplication))
+	router.GET("/api/search_jobs", mid.Authentication(jobHandler.SearchJobs))
+	router.PUT("/api/job/:id", mid.Authentication(mid.RequireRole(jobHandler.ReplaceJob, model.RoleRecruiter, model.RoleCompanyAdmin, model.RoleAdmin)))
+	router.PATCH("/api/job/:id", mid.Authentication(mid.RequireRole(jobHandler.UpdateJob, model.RoleRecruiter, model.RoleCompanyAdmin, model.RoleAdmin)))
+	router.POST("/api/job/:id/close", mid.Authentication(mid.RequireRole(jobHandler.CloseJob, model.RoleRecruiter, model.RoleCompanyAdmin, model.RoleAdmin)))
+	router.POST("/api/job/:id/reopen", mid.Authentication(mid.RequireRole(jobHandler.ReopenJob, model.RoleRecruiter, model.RoleCompanyAdmin, model.RoleAdmin)))
+	router.POST("/api/job/:id/publish", mid.Authentication(mid.RequireRole(mid.RequireVerifiedEmail(jobHandler.PublishJob), model.RoleRecruiter, model.RoleCompanyAdmin, model.RoleAdmin)))
+	router.POST("/api/job/:id/archive", mid.Authentication(mid.RequireRole(jobHandler.ArchiveJob, model.RoleRecruiter, model.RoleCompanyAdmin, model.RoleAdmin)))
+	router.DELETE("/api/job/:id", mid.Authentication(mid.RequireRole(jobHandler.DeleteJob, model.RoleRecruiter, model.RoleCompanyAdmin, model.RoleAdmin)))
+	router.GET("/api/process_application", mid.Authentication(mid.RequireRole(jobHandler.ProcessJobApplication, model.RoleRecruiter, model.RoleCompanyAdmin, model.RoleAdmin)))
+
+	router.GET("/api/get_applications_by_job_id/:id", mid.Authentication(mid.RequireRole(applicationHandler.ViewApplicationsByJobID, model.RoleRecruiter, model.RoleCompanyAdmin, model.RoleAdmin)))
+	router.GET("/api/get_application/:id", mid.Authentication(mid.RequireRole(applicationHandler.ViewApplicationByID, model.RoleRecruiter, model.RoleCompanyAdmin, model.RoleAdmin)))
+	router.POST("/api/application/:id/status", mid.Authentication(mid.RequireRole(applicationHandler.MoveApplication, model.RoleRecruiter, model.RoleCompanyAdmin, model.RoleAdmin)))
+	router.GET("/api/application/:id/history", mid.Authentication(mid.RequireRole(applicationHandler.ViewApplicationHistory, model.RoleRecruiter, model.RoleCompanyAdmin, model.RoleAdmin)))
+
+	router.GET("/api/profile", mid.Authentication(mid.RequireRole(candidateHandler.ViewProfile, model.RoleCandidate)))
+	router.PUT("/api/profile", mid.Authentication(mid.RequireRole(candidateHandler.SaveProfile, model.RoleCandidate)))
+	router.POST("/api/jobs/:id/applications", mid.Authentication(mid.RequireRole(mid.RequireVerifiedEmail(candidateHandler.ApplyToJob), model.RoleCandidate)))
+	router.GET("/api/my/applications", mid.Authentication(mid.RequireRole(candidateHandler.ViewMyApplications, model.RoleCandidate)))
+	router.POST("/api/applications/:id/withdraw", mid.Authentication(mid.RequireRole(candidateHandler.WithdrawApplication, model.RoleCandidate)))
+
+	router.POST("/api/documents", mid.Authentication(mid.RequireRole(documentHandler.UploadDocument, model.RoleCandidate)))
+	router.GET("/api/application/:id/documents", mid.Authentication(mid.RequireRole(documentHandler.ViewApplicationDocuments, model.RoleRecruiter, model.RoleCompanyAdmin, model.RoleAdmin)))
+	router.GET("/api/documents/:id/download", documentHandler.DownloadDocument)
+
+	router.GET("/api/taxonomy/:kind", taxonomyHandler.ViewTaxonomies)
+	router.POST("/api/taxonomy/:kind", mid.Authentication(mid.RequireRole(taxonomyHandler.AddTaxonomy, model.RoleAdmin)))
+	router.PUT("/api/taxonomy/:kind/:id", mid.Authentication(mid.RequireRole(taxonomyHandler.RenameTaxonomy, model.RoleAdmin)))
+	router.POST("/api/taxonomy/:kind/:id/retire", mid.Authentication(mid.RequireRole(taxonomyHandler.RetireTaxonomy, model.RoleAdmin)))
+	router.POST("/api/taxonomy/:kind/:id/restore", mid.Authentication(mid.RequireRole(taxonomyHandler.RestoreTaxonomy, model.RoleAdmin)))
 
 	return router
 }
 
+// jwks publishes the public signing keys so other services can verify our tokens, it may be
+// cached for a few minutes so a new key is published before it starts signing
+func jwks(auth authentication.Authenticaton) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, auth.JWKS())
+	}
+}
+
 func check(c *gin.Context) {
 
 	time.Sleep(time.Second * 3)
@@ -12,7 +12,6 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/rs/zerolog/log"
 )
 
@@ -21,7 +20,15 @@ type JobHandler interface {
 	ViewJobByCompanyId(c *gin.Context)
 	ViewJobByJobID(c *gin.Context)
 	ViewAllJobs(c *gin.Context)
+	SearchJobs(c *gin.Context)
 	ProcessJobApplication(c *gin.Context)
+	ReplaceJob(c *gin.Context)
+	UpdateJob(c *gin.Context)
+	CloseJob(c *gin.Context)
+	ReopenJob(c *gin.Context)
+	PublishJob(c *gin.Context)
+	ArchiveJob(c *gin.Context)
+	DeleteJob(c *gin.Context)
 }
 
 func NewJobHandler(serviceJob service.JobService) (JobHandler, error) {
@@ -46,13 +53,20 @@ func (h *Handler) CreateJobByCompanyID(c *gin.Context) {
 		return
 	}
 
-	_, ok = ctx.Value(authentication.AuthKey).(jwt.RegisteredClaims)
+	claims, ok := authentication.ClaimsFromContext(ctx)
 	if !ok {
 		log.Info().Str("trace Id : ", traceId).Msg("login first")
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
 		return
 	}
 
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace Id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
 	id := c.Param("id")
 
 	cId, err := strconv.ParseUint(id, 10, 64)
@@ -80,7 +94,23 @@ func (h *Handler) CreateJobByCompanyID(c *gin.Context) {
 		return
 	}
 
-	jodResponse, err := h.serviceJob.CreateJobByCompanyId(jobData, uint(cId))
+	jodResponse, err := h.serviceJob.CreateJobByCompanyId(jobData, uint(cId), uID)
+	if errors.Is(err, service.ErrNotCompanyMember) {
+		log.Error().Err(err).Str("trace id :", traceId).Msg("user cannot post jobs for the company")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error ": http.StatusText(http.StatusForbidden)})
+		return
+	}
+	var refErr *service.InvalidReferencesError
+	if errors.As(err, &refErr) {
+		log.Error().Err(err).Str("trace id :", traceId).Msg("job refers to invalid values")
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error ": refErr.Error(), "invalid": refErr.References})
+		return
+	}
+	if errors.Is(err, service.ErrInvalidExpiry) {
+		log.Error().Err(err).Str("trace id :", traceId).Msg("job expiry date has passed")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": err.Error()})
+		return
+	}
 	if err != nil {
 		log.Error().Err(err).Str("trace id :", traceId).Msg("error in job creation")
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
@@ -102,13 +132,20 @@ func (h *Handler) ViewJobByCompanyId(c *gin.Context) {
 		return
 	}
 
-	_, ok = ctx.Value(authentication.AuthKey).(jwt.RegisteredClaims)
+	claims, ok := authentication.ClaimsFromContext(ctx)
 	if !ok {
 		log.Info().Str("trace id : ", traceId).Msg("login unsuccessful")
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
 		return
 	}
 
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
 	id := c.Param("id")
 
 	cID, err := strconv.ParseUint(id, 10, 64)
@@ -118,7 +155,7 @@ func (h *Handler) ViewJobByCompanyId(c *gin.Context) {
 		return
 	}
 
-	jobData, err := h.serviceJob.ViewJobByCompanyID(uint(cID))
+	jobData, err := h.serviceJob.ViewJobByCompanyID(uint(cID), uID)
 	if err != nil {
 		log.Error().Err(err).Str("trace id : ", traceId)
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
@@ -139,13 +176,20 @@ func (h *Handler) ViewJobByJobID(c *gin.Context) {
 		return
 	}
 
-	_, ok = ctx.Value(authentication.AuthKey).(jwt.RegisteredClaims)
+	claims, ok := authentication.ClaimsFromContext(ctx)
 	if !ok {
 		log.Info().Str("trace id : ", traceID).Msg("login first")
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
 		return
 	}
 
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceID).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
 	id := c.Param("id")
 
 	jID, err := strconv.ParseUint(id, 10, 64)
@@ -155,7 +199,12 @@ func (h *Handler) ViewJobByJobID(c *gin.Context) {
 		return
 	}
 
-	jobData, err := h.serviceJob.ViewJobByJobID(uint(jID))
+	jobData, err := h.serviceJob.ViewJobByJobID(uint(jID), uID)
+	if errors.Is(err, service.ErrJobNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceID).Msg("job not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
 	if err != nil {
 		log.Error().Err(err).Str("trace id : ", traceID)
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
@@ -176,14 +225,31 @@ func (h *Handler) ViewAllJobs(c *gin.Context) {
 		return
 	}
 
-	_, ok = ctx.Value(authentication.AuthKey).(jwt.RegisteredClaims)
+	_, ok = authentication.ClaimsFromContext(ctx)
 	if !ok {
 		log.Info().Str("trace id : ", traceId).Msg("login first")
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
 		return
 	}
 
-	jobsData, err := h.serviceJob.ViewAllJobs()
+	var filter model.JobFilter
+
+	err := c.ShouldBindQuery(&filter)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in reading filter")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(filter)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in validating filter")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	jobsData, err := h.serviceJob.ViewAllJobs(filter)
 	if err != nil {
 		log.Error().Err(err).Str("tracr id : ", traceId)
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
@@ -192,6 +258,51 @@ func (h *Handler) ViewAllJobs(c *gin.Context) {
 	c.JSON(http.StatusOK, jobsData)
 }
 
+func (h *Handler) SearchJobs(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("error missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	_, ok = authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	var search model.JobSearch
+
+	err := c.ShouldBindQuery(&search)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in reading search")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(search)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in validating search")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	results, err := h.serviceJob.SearchJobs(search)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in searching jobs")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
 func (h *Handler) ProcessJobApplication(c *gin.Context) {
 
 	ctx := c.Request.Context()
@@ -203,40 +314,401 @@ func (h *Handler) ProcessJobApplication(c *gin.Context) {
 		return
 	}
 
-	_, ok = ctx.Value(authentication.AuthKey).(jwt.RegisteredClaims)
+	claims, ok := authentication.ClaimsFromContext(ctx)
 	if !ok {
 		log.Info().Str("tracr id : ", traceId).Msg("login first")
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
 		return
 	}
 
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
 	var applications []model.NewUserApplication
 
-	err := json.NewDecoder(c.Request.Body).Decode(&applications)
+	err = json.NewDecoder(c.Request.Body).Decode(&applications)
 	if err != nil {
 		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in decoding")
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
 		return
 	}
 
+	//every application gets an outcome in the response, the invalid ones are not passed on
+	results := make([]model.ProcessedApplication, len(applications))
+	var valid []model.NewUserApplication
+	var validIndex []int
+
 	validate := validator.New()
-	// log.Debug().Interface("body", applications).Msg("request body")
-	err = validate.Struct(applications)
-	if err != nil {
-		if _, ok := err.(validator.ValidationErrors); ok {
-			log.Error().Err(err).Str("trace id : ", traceId).Msg("error in validaing")
-			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
-			return
+	for i, v := range applications {
+		err = validate.Struct(v)
+		if err != nil {
+			log.Error().Err(err).Str("trace id : ", traceId).Int("index", i).Msg("error in validaing")
+			results[i] = model.ProcessedApplication{Index: i, Outcome: model.OutcomeInvalid, Error: "application has missing or invalid fields", Application: v}
+			continue
+		}
+		valid = append(valid, v)
+		validIndex = append(validIndex, i)
+	}
+
+	if len(valid) > 0 {
+		for i, v := range h.serviceJob.ProcessApplication(uID, valid) {
+			v.Index = validIndex[i]
+			results[v.Index] = v
 		}
 	}
 
-	jobApplication := h.serviceJob.ProcessApplication(applications)
-	if jobApplication == nil {
-		log.Info().Str("trace id : ", traceId).Msg("all applications rejected")
-		c.JSON(http.StatusBadRequest, gin.H{"error all applications rejected ": http.StatusText(http.StatusBadRequest)})
+	c.JSON(http.StatusOK, results)
+
+}
+
+// ReplaceJob overwrites the whole job with the posted one
+func (h *Handler) ReplaceJob(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	jID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error invalid job id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	var jobData model.NewJobs
+
+	err = json.NewDecoder(c.Request.Body).Decode(&jobData)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in decoding")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
 		return
 	}
 
-	c.JSON(http.StatusOK, jobApplication)
+	validate := validator.New()
+
+	err = validate.Struct(jobData)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in validating job")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	job, err := h.serviceJob.ReplaceJob(uint(jID), uID, jobData)
+	if errors.Is(err, service.ErrJobNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("job not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if errors.Is(err, service.ErrNotCompanyMember) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("user cannot manage the job")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": http.StatusText(http.StatusForbidden)})
+		return
+	}
+	var refErr *service.InvalidReferencesError
+	if errors.As(err, &refErr) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("job refers to invalid values")
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": refErr.Error(), "invalid": refErr.References})
+		return
+	}
+	if errors.Is(err, service.ErrInvalidExpiry) || errors.Is(err, service.ErrInvalidSalary) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid job update")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in updating job")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// UpdateJob changes only the fields present in the request
+func (h *Handler) UpdateJob(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	jID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error invalid job id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	var jobData model.UpdateJob
+
+	err = json.NewDecoder(c.Request.Body).Decode(&jobData)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in decoding")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	validate := validator.New()
+
+	err = validate.Struct(jobData)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in validating job")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	job, err := h.serviceJob.UpdateJob(uint(jID), uID, jobData)
+	if errors.Is(err, service.ErrJobNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("job not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if errors.Is(err, service.ErrNotCompanyMember) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("user cannot manage the job")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": http.StatusText(http.StatusForbidden)})
+		return
+	}
+	var refErr *service.InvalidReferencesError
+	if errors.As(err, &refErr) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("job refers to invalid values")
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": refErr.Error(), "invalid": refErr.References})
+		return
+	}
+	if errors.Is(err, service.ErrInvalidExpiry) || errors.Is(err, service.ErrInvalidSalary) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid job update")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in updating job")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+func (h *Handler) CloseJob(c *gin.Context) {
+	h.setJobClosed(c, true)
+}
+
+func (h *Handler) ReopenJob(c *gin.Context) {
+	h.setJobClosed(c, false)
+}
+
+func (h *Handler) setJobClosed(c *gin.Context, closed bool) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	jID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error invalid job id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	job, err := h.serviceJob.SetJobClosed(uint(jID), uID, closed)
+	if errors.Is(err, service.ErrJobNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("job not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if errors.Is(err, service.ErrNotCompanyMember) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("user cannot manage the job")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": http.StatusText(http.StatusForbidden)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in changing job state")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// PublishJob makes a draft or expired job visible in listings
+func (h *Handler) PublishJob(c *gin.Context) {
+	h.setJobStatus(c, model.JobStatusPublished)
+}
+
+// ArchiveJob takes the job out of listings for good, it is kept for reporting
+func (h *Handler) ArchiveJob(c *gin.Context) {
+	h.setJobStatus(c, model.JobStatusArchived)
+}
+
+func (h *Handler) setJobStatus(c *gin.Context, status string) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	jID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error invalid job id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	job, err := h.serviceJob.SetJobStatus(uint(jID), uID, status)
+	if errors.Is(err, service.ErrJobNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("job not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if errors.Is(err, service.ErrNotCompanyMember) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("user cannot manage the job")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": http.StatusText(http.StatusForbidden)})
+		return
+	}
+	if errors.Is(err, service.ErrInvalidJobStatus) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("job cannot move to the status")
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrInvalidExpiry) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("job expiry date has passed")
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in changing job status")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+func (h *Handler) DeleteJob(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	jID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error invalid job id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	err = h.serviceJob.DeleteJob(uint(jID), uID)
+	if errors.Is(err, service.ErrJobNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("job not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if errors.Is(err, service.ErrNotCompanyMember) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("user cannot manage the job")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": http.StatusText(http.StatusForbidden)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in deleting job")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
 
+	c.JSON(http.StatusOK, gin.H{"msg": "job deleted"})
 }
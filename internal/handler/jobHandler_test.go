@@ -11,6 +11,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
@@ -77,7 +78,7 @@ func TestHandler_CreateJobByCompanyID(t *testing.T) {
 				))
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Params = append(c.Params, gin.Param{Key: "id", Value: "abc"})
 				c.Request = httpRequest
@@ -109,7 +110,7 @@ func TestHandler_CreateJobByCompanyID(t *testing.T) {
 				))
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
 				c.Request = httpRequest
@@ -141,7 +142,7 @@ func TestHandler_CreateJobByCompanyID(t *testing.T) {
 				))
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
 				c.Request = httpRequest
@@ -173,7 +174,7 @@ func TestHandler_CreateJobByCompanyID(t *testing.T) {
 				))
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Params = append(c.Params, gin.Param{Key: "id", Value: "abc"})
 				c.Request = httpRequest
@@ -205,7 +206,7 @@ func TestHandler_CreateJobByCompanyID(t *testing.T) {
 				))
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
 				c.Request = httpRequest
@@ -213,7 +214,7 @@ func TestHandler_CreateJobByCompanyID(t *testing.T) {
 				mc := gomock.NewController(t)
 				mj := service.NewMockJobService(mc)
 
-				mj.EXPECT().CreateJobByCompanyId(gomock.Any(), gomock.Any()).Return(model.Response{}, errors.New("error")).AnyTimes()
+				mj.EXPECT().CreateJobByCompanyId(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Response{}, errors.New("error")).AnyTimes()
 
 				return c, rr, mj
 			},
@@ -242,7 +243,7 @@ func TestHandler_CreateJobByCompanyID(t *testing.T) {
 				))
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
 				c.Request = httpRequest
@@ -250,7 +251,7 @@ func TestHandler_CreateJobByCompanyID(t *testing.T) {
 				mc := gomock.NewController(t)
 				mj := service.NewMockJobService(mc)
 
-				mj.EXPECT().CreateJobByCompanyId(gomock.Any(), gomock.Any()).Return(model.Response{}, nil).AnyTimes()
+				mj.EXPECT().CreateJobByCompanyId(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Response{}, nil).AnyTimes()
 
 				return c, rr, mj
 			},
@@ -317,7 +318,7 @@ func TestHandler_ViewJobByCompanyId(t *testing.T) {
 				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Params = append(c.Params, gin.Param{Key: "id", Value: "abc"})
 				c.Request = httpRequest
@@ -335,7 +336,7 @@ func TestHandler_ViewJobByCompanyId(t *testing.T) {
 				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
 				c.Request = httpRequest
@@ -343,7 +344,7 @@ func TestHandler_ViewJobByCompanyId(t *testing.T) {
 				mc := gomock.NewController(t)
 				mj := service.NewMockJobService(mc)
 
-				mj.EXPECT().ViewJobByCompanyID(gomock.Any()).Return([]model.Job{}, errors.New("error"))
+				mj.EXPECT().ViewJobByCompanyID(uint(1), uint(1)).Return([]model.Job{}, errors.New("error"))
 
 				return c, rr, mj
 			},
@@ -358,7 +359,7 @@ func TestHandler_ViewJobByCompanyId(t *testing.T) {
 				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
 				c.Request = httpRequest
@@ -366,7 +367,7 @@ func TestHandler_ViewJobByCompanyId(t *testing.T) {
 				mc := gomock.NewController(t)
 				mj := service.NewMockJobService(mc)
 
-				mj.EXPECT().ViewJobByCompanyID(gomock.Any()).Return([]model.Job{}, nil)
+				mj.EXPECT().ViewJobByCompanyID(uint(1), uint(1)).Return([]model.Job{}, nil)
 
 				return c, rr, mj
 			},
@@ -433,7 +434,7 @@ func TestHandler_ViewJobByJobID(t *testing.T) {
 				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Params = append(c.Params, gin.Param{Key: "id", Value: "abc"})
 				c.Request = httpRequest
@@ -443,6 +444,29 @@ func TestHandler_ViewJobByJobID(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse:   `{"error":"Bad Request"}`,
 		},
+		{
+			name: "job not found",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mj := service.NewMockJobService(mc)
+
+				mj.EXPECT().ViewJobByJobID(uint(1), uint(1)).Return(model.Job{}, service.ErrJobNotFound)
+
+				return c, rr, mj
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"error":"Not Found"}`,
+		},
 		{
 			name: "failure",
 			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
@@ -451,7 +475,7 @@ func TestHandler_ViewJobByJobID(t *testing.T) {
 				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
 				c.Request = httpRequest
@@ -459,7 +483,7 @@ func TestHandler_ViewJobByJobID(t *testing.T) {
 				mc := gomock.NewController(t)
 				mj := service.NewMockJobService(mc)
 
-				mj.EXPECT().ViewJobByJobID(gomock.Any()).Return(model.Job{}, errors.New("error"))
+				mj.EXPECT().ViewJobByJobID(uint(1), uint(1)).Return(model.Job{}, errors.New("error"))
 
 				return c, rr, mj
 			},
@@ -474,7 +498,7 @@ func TestHandler_ViewJobByJobID(t *testing.T) {
 				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
 				c.Request = httpRequest
@@ -482,7 +506,7 @@ func TestHandler_ViewJobByJobID(t *testing.T) {
 				mc := gomock.NewController(t)
 				mj := service.NewMockJobService(mc)
 
-				mj.EXPECT().ViewJobByJobID(gomock.Any()).Return(model.Job{}, nil)
+				mj.EXPECT().ViewJobByJobID(uint(1), uint(1)).Return(model.Job{}, nil)
 
 				return c, rr, mj
 			},
@@ -549,14 +573,14 @@ func TestHandler_ViewAllJobs(t *testing.T) {
 				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Request = httpRequest
 
 				mc := gomock.NewController(t)
 				mj := service.NewMockJobService(mc)
 
-				mj.EXPECT().ViewAllJobs().Return(nil, errors.New("error"))
+				mj.EXPECT().ViewAllJobs(gomock.Any()).Return(model.JobList{}, errors.New("error"))
 
 				return c, rr, mj
 			},
@@ -571,19 +595,61 @@ func TestHandler_ViewAllJobs(t *testing.T) {
 				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Request = httpRequest
 
 				mc := gomock.NewController(t)
 				mj := service.NewMockJobService(mc)
 
-				mj.EXPECT().ViewAllJobs().Return([]model.Job{}, nil)
+				mj.EXPECT().ViewAllJobs(gomock.Any()).Return(model.JobList{Jobs: []model.Job{}, Page: 1, PageSize: 20}, nil)
 
 				return c, rr, mj
 			},
 			expectedStatusCode: http.StatusOK,
-			expectedResponse:   `[]`,
+			expectedResponse:   `{"jobs":[],"total":0,"page":1,"pageSize":20}`,
+		},
+		{
+			name: "invalid filter",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com?page_size=500", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Bad Request"}`,
+		},
+		{
+			name: "filter read from the query",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com?page=2&location=1&location=3&min_experience=2&posted_since=2023-11-01&sort=oldest", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mj := service.NewMockJobService(mc)
+
+				experience := 2
+				since := time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)
+				mj.EXPECT().ViewAllJobs(model.JobFilter{Page: 2, Location: []uint{1, 3}, MinExperience: &experience, PostedSince: &since, Sort: model.JobSortOldest}).
+					Return(model.JobList{Jobs: []model.Job{}, Total: 21, Page: 2, PageSize: 20}, nil)
+
+				return c, rr, mj
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"jobs":[],"total":21,"page":2,"pageSize":20}`,
 		},
 	}
 	for _, tt := range tests {
@@ -637,6 +703,23 @@ func TestHandler_ProcessJobApplication(t *testing.T) {
 			expectedStatusCode: http.StatusUnauthorized,
 			expectedResponse:   `{"error":"Unauthorized"}`,
 		},
+		{
+			name: "invalid user id in claims",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"error":"Unauthorized"}`,
+		},
 		{
 			name: "error in decoding",
 			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
@@ -708,7 +791,7 @@ func TestHandler_ProcessJobApplication(t *testing.T) {
 				))
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Request = httpRequest
 
@@ -718,7 +801,7 @@ func TestHandler_ProcessJobApplication(t *testing.T) {
 			expectedResponse:   `{"error":"Bad Request"}`,
 		},
 		{
-			name: "error in validating",
+			name: "invalid applications are not processed",
 			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
 				rr := httptest.NewRecorder()
 				c, _ := gin.CreateTestContext(rr)
@@ -788,23 +871,21 @@ func TestHandler_ProcessJobApplication(t *testing.T) {
 				))
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Request = httpRequest
 
 				mc := gomock.NewController(t)
 				mj := service.NewMockJobService(mc)
 
-				mj.EXPECT().ProcessApplication(gomock.Any()).Return(nil).AnyTimes()
-
 				return c, rr, mj
 
 			},
-			expectedStatusCode: http.StatusBadRequest,
-			expectedResponse:   `{"error all applications rejected ":"Bad Request"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `[{"index":0,"outcome":"invalid","error":"application has missing or invalid fields","applicationID":0,"application":{"name":"","age":"","jid":7,"userID":0,"job_application":{"noticePeriod":100,"location":[1,3],"technologyStack":[1,3],"experience":90,"qualifications":[1,2],"shifts":[1,2],"jobtype":[1,2],"expectedSalary":0,"workMode":null}},"score":0,"accepted":false,"report":null},{"index":1,"outcome":"invalid","error":"application has missing or invalid fields","applicationID":0,"application":{"name":"","age":"","jid":8,"userID":0,"job_application":{"noticePeriod":30,"location":[1,2],"technologyStack":[1,2],"experience":5,"qualifications":[1,2],"shifts":[1,2],"jobtype":[1,2],"expectedSalary":0,"workMode":null}},"score":0,"accepted":false,"report":null}]`,
 		},
 		{
-			name: "error in decoding",
+			name: "failed applications report their outcome",
 			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
 				rr := httptest.NewRecorder()
 				c, _ := gin.CreateTestContext(rr)
@@ -874,22 +955,25 @@ func TestHandler_ProcessJobApplication(t *testing.T) {
 				))
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Request = httpRequest
 
 				mc := gomock.NewController(t)
 				mj := service.NewMockJobService(mc)
 
-				mj.EXPECT().ProcessApplication(gomock.Any()).Return(nil).AnyTimes()
+				mj.EXPECT().ProcessApplication(uint(1), gomock.Len(2)).Return([]model.ProcessedApplication{
+					{Index: 0, Outcome: model.OutcomeInternalError, Error: "could not save the application"},
+					{Index: 1, Outcome: model.OutcomeRejected, ApplicationID: 2, Score: 40},
+				})
 
 				return c, rr, mj
 			},
-			expectedStatusCode: http.StatusBadRequest,
-			expectedResponse:   `{"error all applications rejected ":"Bad Request"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `[{"index":0,"outcome":"internal_error","error":"could not save the application","applicationID":0,"application":{"name":"","age":"","jid":0,"userID":0,"job_application":{"noticePeriod":0,"location":null,"technologyStack":null,"experience":0,"qualifications":null,"shifts":null,"jobtype":null,"expectedSalary":0,"workMode":null}},"score":0,"accepted":false,"report":null},{"index":1,"outcome":"rejected","applicationID":2,"application":{"name":"","age":"","jid":0,"userID":0,"job_application":{"noticePeriod":0,"location":null,"technologyStack":null,"experience":0,"qualifications":null,"shifts":null,"jobtype":null,"expectedSalary":0,"workMode":null}},"score":40,"accepted":false,"report":null}]`,
 		},
 		{
-			name: "error in decoding",
+			name: "only the valid applications are processed",
 			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
 				rr := httptest.NewRecorder()
 				c, _ := gin.CreateTestContext(rr)
@@ -897,7 +981,7 @@ func TestHandler_ProcessJobApplication(t *testing.T) {
 					`
 					[
     {
-        "name": "John Doe 1",
+        "name": "",
         "age": "30",
         "jid": 7,
         "job_application": {
@@ -959,19 +1043,19 @@ func TestHandler_ProcessJobApplication(t *testing.T) {
 				))
 				ctx := httpRequest.Context()
 				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
-				ctx = context.WithValue(ctx, authentication.AuthKey, jwt.RegisteredClaims{})
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
 				httpRequest = httpRequest.WithContext(ctx)
 				c.Request = httpRequest
 
 				mc := gomock.NewController(t)
 				mj := service.NewMockJobService(mc)
 
-				mj.EXPECT().ProcessApplication(gomock.Any()).Return([]model.NewUserApplication{}).AnyTimes()
+				mj.EXPECT().ProcessApplication(uint(1), gomock.Len(1)).Return([]model.ProcessedApplication{{Index: 0, Outcome: model.OutcomeAccepted, ApplicationID: 3, Score: 80, Accepted: true}})
 
 				return c, rr, mj
 			},
 			expectedStatusCode: http.StatusOK,
-			expectedResponse:   `[]`,
+			expectedResponse:   `[{"index":0,"outcome":"invalid","error":"application has missing or invalid fields","applicationID":0,"application":{"name":"","age":"30","jid":7,"userID":0,"job_application":{"noticePeriod":100,"location":[1,3],"technologyStack":[1,3],"experience":90,"qualifications":[1,2],"shifts":[1,2],"jobtype":[1,2],"expectedSalary":0,"workMode":null}},"score":0,"accepted":false,"report":null},{"index":1,"outcome":"accepted","applicationID":3,"application":{"name":"","age":"","jid":0,"userID":0,"job_application":{"noticePeriod":0,"location":null,"technologyStack":null,"experience":0,"qualifications":null,"shifts":null,"jobtype":null,"expectedSalary":0,"workMode":null}},"score":80,"accepted":true,"report":null}]`,
 		},
 	}
 	for _, tt := range tests {
@@ -987,3 +1071,415 @@ func TestHandler_ProcessJobApplication(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_UpdateJob(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.JobService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "error in validating",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPatch, "http://test.com", strings.NewReader(`{"jobName":""}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Bad Request"}`,
+		},
+		{
+			name: "job not found",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPatch, "http://test.com", strings.NewReader(`{"jobName":"developer"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mj := service.NewMockJobService(mc)
+
+				mj.EXPECT().UpdateJob(uint(1), uint(1), gomock.Any()).Return(model.Job{}, service.ErrJobNotFound)
+
+				return c, rr, mj
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"error":"Not Found"}`,
+		},
+		{
+			name: "not a company member",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPatch, "http://test.com", strings.NewReader(`{"jobName":"developer"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mj := service.NewMockJobService(mc)
+
+				mj.EXPECT().UpdateJob(uint(1), uint(1), gomock.Any()).Return(model.Job{}, service.ErrNotCompanyMember)
+
+				return c, rr, mj
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"error":"Forbidden"}`,
+		},
+		{
+			name: "invalid references",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPatch, "http://test.com", strings.NewReader(`{"location":[1,9]}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mj := service.NewMockJobService(mc)
+
+				mj.EXPECT().UpdateJob(uint(1), uint(1), gomock.Any()).Return(model.Job{}, &service.InvalidReferencesError{References: model.InvalidReferences{Location: []uint{9}}})
+
+				return c, rr, mj
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse:   `{"error":"request refers to values which do not exist","invalid":{"location":[9]}}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPatch, "http://test.com", strings.NewReader(`{"jobName":"developer"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mj := service.NewMockJobService(mc)
+
+				name := "developer"
+				mj.EXPECT().UpdateJob(uint(1), uint(1), model.UpdateJob{Jobname: &name}).Return(model.Job{Jobname: "developer"}, nil)
+
+				return c, rr, mj
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"ID":0,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"company":{"ID":0,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"companyName":"","address":"","domain":""},"cid":0,"jobname":"developer","min_notice_period":0,"max_notice_period":0,"location":null,"skills":null,"description":"","min_experience":0,"max_experience":0,"qualifications":null,"shifts":null,"jobtype":null,"matchCriteria":{"weights":null,"mustHave":null,"minScore":0},"closed":false,"min_salary":0,"max_salary":0,"currency":"","pay_period":"","work_mode":"","status":"","expiresAt":null}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, ms := tt.setup()
+			h := Handler{
+				serviceJob: ms,
+			}
+			h.UpdateJob(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestHandler_DeleteJob(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.JobService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "invalid job id",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodDelete, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "abc"})
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Bad Request"}`,
+		},
+		{
+			name: "error in deleting job",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodDelete, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mj := service.NewMockJobService(mc)
+
+				mj.EXPECT().DeleteJob(uint(1), uint(1)).Return(errors.New("error"))
+
+				return c, rr, mj
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error":"Internal Server Error"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodDelete, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mj := service.NewMockJobService(mc)
+
+				mj.EXPECT().DeleteJob(uint(1), uint(1)).Return(nil)
+
+				return c, rr, mj
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"msg":"job deleted"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, ms := tt.setup()
+			h := Handler{
+				serviceJob: ms,
+			}
+			h.DeleteJob(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestHandler_PublishJob(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.JobService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "invalid job id",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "abc"})
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Bad Request"}`,
+		},
+		{
+			name: "job cannot be published",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mj := service.NewMockJobService(mc)
+
+				mj.EXPECT().SetJobStatus(uint(1), uint(1), model.JobStatusPublished).Return(model.Job{}, service.ErrInvalidJobStatus)
+
+				return c, rr, mj
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `{"error":"job cannot move to the requested status"}`,
+		},
+		{
+			name: "expiry date has passed",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mj := service.NewMockJobService(mc)
+
+				mj.EXPECT().SetJobStatus(uint(1), uint(1), model.JobStatusPublished).Return(model.Job{}, service.ErrInvalidExpiry)
+
+				return c, rr, mj
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `{"error":"job expiry date has already passed"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "1"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mj := service.NewMockJobService(mc)
+
+				mj.EXPECT().SetJobStatus(uint(1), uint(1), model.JobStatusPublished).Return(model.Job{Status: model.JobStatusPublished}, nil)
+
+				return c, rr, mj
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"ID":0,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"company":{"ID":0,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"companyName":"","address":"","domain":""},"cid":0,"jobname":"","min_notice_period":0,"max_notice_period":0,"location":null,"skills":null,"description":"","min_experience":0,"max_experience":0,"qualifications":null,"shifts":null,"jobtype":null,"matchCriteria":{"weights":null,"mustHave":null,"minScore":0},"closed":false,"min_salary":0,"max_salary":0,"currency":"","pay_period":"","work_mode":"","status":"published","expiresAt":null}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, ms := tt.setup()
+			h := Handler{
+				serviceJob: ms,
+			}
+			h.PublishJob(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestHandler_SearchJobs(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.JobService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "missing keywords",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com?location=1", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Bad Request"}`,
+		},
+		{
+			name: "failure",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com?q=golang", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mj := service.NewMockJobService(mc)
+
+				mj.EXPECT().SearchJobs(gomock.Any()).Return(model.JobSearchList{}, errors.New("error"))
+
+				return c, rr, mj
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error":"Internal Server Error"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.JobService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com?q=golang&company_id=2", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mj := service.NewMockJobService(mc)
+
+				mj.EXPECT().SearchJobs(model.JobSearch{Query: "golang", JobFilter: model.JobFilter{CompanyID: 2}}).
+					Return(model.JobSearchList{Results: []model.JobSearchResult{}, Page: 1, PageSize: 20}, nil)
+
+				return c, rr, mj
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"results":[],"total":0,"page":1,"pageSize":20}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, mj := tt.setup()
+			h := Handler{
+				serviceJob: mj,
+			}
+			h.SearchJobs(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"job-portal-api/internal/authentication"
+	"job-portal-api/internal/middleware"
+	"job-portal-api/internal/model"
+	"job-portal-api/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog/log"
+)
+
+type TaxonomyHandler interface {
+	ViewTaxonomies(c *gin.Context)
+	AddTaxonomy(c *gin.Context)
+	RenameTaxonomy(c *gin.Context)
+	RetireTaxonomy(c *gin.Context)
+	RestoreTaxonomy(c *gin.Context)
+}
+
+func NewTaxonomyHandler(serviceTaxonomy service.TaxonomyService) (TaxonomyHandler, error) {
+	if serviceTaxonomy == nil {
+		log.Info().Msg("taxonomy service cannot be nil")
+		return nil, errors.New("taxonomy service cannot be nil")
+	}
+
+	return &Handler{
+		serviceTaxonomy: serviceTaxonomy,
+	}, nil
+}
+
+// ViewTaxonomies lists the values of a taxonomy for everyone, retired values are only listed when asked for
+func (h *Handler) ViewTaxonomies(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	includeRetired := false
+	if value := c.Query("include_retired"); value != "" {
+		var err error
+		includeRetired, err = strconv.ParseBool(value)
+		if err != nil {
+			log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid include retired")
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+			return
+		}
+	}
+
+	taxonomies, err := h.serviceTaxonomy.ViewTaxonomies(c.Param("kind"), includeRetired)
+	if errors.Is(err, service.ErrUnknownTaxonomy) || errors.Is(err, service.ErrTaxonomyNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("taxonomy not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in fetching taxonomies")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, taxonomies)
+}
+
+func (h *Handler) AddTaxonomy(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	_, ok = authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	var taxonomy model.TaxonomyName
+
+	err := json.NewDecoder(c.Request.Body).Decode(&taxonomy)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in decoding")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(taxonomy)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in validating taxonomy")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	taxonomyData, err := h.serviceTaxonomy.AddTaxonomy(c.Param("kind"), taxonomy)
+	if errors.Is(err, service.ErrUnknownTaxonomy) || errors.Is(err, service.ErrTaxonomyNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("taxonomy not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if errors.Is(err, service.ErrEmptyTaxonomyName) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("blank taxonomy name")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrTaxonomyExists) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("taxonomy already exists")
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in adding taxonomy")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, taxonomyData)
+}
+
+func (h *Handler) RenameTaxonomy(c *gin.Context) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	_, ok = authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid taxonomy id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	var taxonomy model.TaxonomyName
+
+	err = json.NewDecoder(c.Request.Body).Decode(&taxonomy)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in decoding")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(taxonomy)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in validating taxonomy")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	taxonomyData, err := h.serviceTaxonomy.RenameTaxonomy(c.Param("kind"), uint(id), taxonomy)
+	if errors.Is(err, service.ErrUnknownTaxonomy) || errors.Is(err, service.ErrTaxonomyNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("taxonomy not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if errors.Is(err, service.ErrEmptyTaxonomyName) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("blank taxonomy name")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrTaxonomyExists) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("taxonomy already exists")
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in renaming taxonomy")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, taxonomyData)
+}
+
+func (h *Handler) RetireTaxonomy(c *gin.Context) {
+	h.setTaxonomyRetired(c, true)
+}
+
+func (h *Handler) RestoreTaxonomy(c *gin.Context) {
+	h.setTaxonomyRetired(c, false)
+}
+
+func (h *Handler) setTaxonomyRetired(c *gin.Context, retired bool) {
+
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace id")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	_, ok = authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid taxonomy id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	taxonomyData, err := h.serviceTaxonomy.SetTaxonomyRetired(c.Param("kind"), uint(id), retired)
+	if errors.Is(err, service.ErrUnknownTaxonomy) || errors.Is(err, service.ErrTaxonomyNotFound) {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("taxonomy not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("error in retiring taxonomy")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, taxonomyData)
+}
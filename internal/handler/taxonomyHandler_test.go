@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"job-portal-api/internal/authentication"
+	"job-portal-api/internal/middleware"
+	"job-portal-api/internal/model"
+	"job-portal-api/internal/service"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestHandler_ViewTaxonomies(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.TaxonomyService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "missing trace id",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.TaxonomyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error":"Internal Server Error"}`,
+		},
+		{
+			name: "unknown kind",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.TaxonomyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "kind", Value: "salary"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mt := service.NewMockTaxonomyService(mc)
+
+				mt.EXPECT().ViewTaxonomies("salary", false).Return(nil, service.ErrUnknownTaxonomy)
+
+				return c, rr, mt
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"error":"Not Found"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.TaxonomyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://test.com?include_retired=true", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "kind", Value: "location"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mt := service.NewMockTaxonomyService(mc)
+
+				mt.EXPECT().ViewTaxonomies(model.TaxonomyLocation, true).Return([]model.Taxonomy{{ID: 1, Name: "bangalore", Retired: true}}, nil)
+
+				return c, rr, mt
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `[{"id":1,"name":"bangalore","retired":true}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, ms := tt.setup()
+			h := Handler{
+				serviceTaxonomy: ms,
+			}
+			h.ViewTaxonomies(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestHandler_AddTaxonomy(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.TaxonomyService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "missing jwt claims",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.TaxonomyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", nil)
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"error":"Unauthorized"}`,
+		},
+		{
+			name: "error in validating",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.TaxonomyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", strings.NewReader(`{"name":""}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "kind", Value: "shift"})
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"Bad Request"}`,
+		},
+		{
+			name: "failure",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.TaxonomyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", strings.NewReader(`{"name":"night"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "kind", Value: "shift"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mt := service.NewMockTaxonomyService(mc)
+
+				mt.EXPECT().AddTaxonomy(model.TaxonomyShift, model.TaxonomyName{Name: "night"}).Return(model.Taxonomy{}, errors.New("error"))
+
+				return c, rr, mt
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error":"Internal Server Error"}`,
+		},
+		{
+			name: "name already exists",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.TaxonomyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", strings.NewReader(`{"name":"night"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "kind", Value: "shift"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mt := service.NewMockTaxonomyService(mc)
+
+				mt.EXPECT().AddTaxonomy(model.TaxonomyShift, model.TaxonomyName{Name: "night"}).Return(model.Taxonomy{}, service.ErrTaxonomyExists)
+
+				return c, rr, mt
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `{"error":"taxonomy value already exists"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.TaxonomyService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://test.com", strings.NewReader(`{"name":"night"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "123")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Params = append(c.Params, gin.Param{Key: "kind", Value: "shift"})
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				mt := service.NewMockTaxonomyService(mc)
+
+				mt.EXPECT().AddTaxonomy(model.TaxonomyShift, model.TaxonomyName{Name: "night"}).Return(model.Taxonomy{ID: 3, Name: "night"}, nil)
+
+				return c, rr, mt
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"id":3,"name":"night","retired":false}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, ms := tt.setup()
+			h := Handler{
+				serviceTaxonomy: ms,
+			}
+			h.AddTaxonomy(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
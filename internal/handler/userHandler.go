@@ -3,10 +3,12 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"job-portal-api/internal/authentication"
 	"job-portal-api/internal/middleware"
 	"job-portal-api/internal/model"
 	"job-portal-api/internal/service"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -17,6 +19,14 @@ import (
 type UserHandler interface {
 	Signup(c *gin.Context)
 	login(c *gin.Context)
+	Refresh(c *gin.Context)
+	Logout(c *gin.Context)
+	LogoutEverywhere(c *gin.Context)
+	ForgotPassword(c *gin.Context)
+	ResetPassword(c *gin.Context)
+	VerifyEmail(c *gin.Context)
+	ResendVerification(c *gin.Context)
+	UpdateUserRole(c *gin.Context)
 }
 
 func NewUserHandler(serviceUser service.UserService) (UserHandler, error) {
@@ -94,11 +104,340 @@ func (h *Handler) login(c *gin.Context) {
 		return
 	}
 
-	token, err := h.serviceUser.Userlogin(userData)
+	tokens, err := h.serviceUser.Userlogin(userData)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token ": token})
+	c.JSON(http.StatusOK, tokenResponse(tokens))
+}
+
+// tokenResponse keeps the access token under the key login has always used
+func tokenResponse(tokens model.TokenPair) gin.H {
+	return gin.H{"token ": tokens.AccessToken, "refreshToken": tokens.RefreshToken, "expiresIn": tokens.ExpiresIn}
+}
+
+// Refresh swaps a refresh token for a new access token and refresh token
+func (h *Handler) Refresh(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace ID")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	var request model.RefreshRequest
+
+	err := json.NewDecoder(c.Request.Body).Decode(&request)
+	if err != nil {
+		log.Error().Err(err).Str("trace Id :", traceId).Msg("error in decoding")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("error in validating")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	tokens, err := h.serviceUser.RefreshSession(ctx, request.RefreshToken)
+	if errors.Is(err, service.ErrInvalidRefreshToken) {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("invalid refresh token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("error in refreshing session")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenResponse(tokens))
+}
+
+// Logout ends the session of the access token used for the request
+func (h *Handler) Logout(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace ID")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	err := h.serviceUser.Logout(ctx, claims)
+	if err != nil {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("error in logging out")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// LogoutEverywhere ends every session of the logged in user
+func (h *Handler) LogoutEverywhere(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace ID")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	err = h.serviceUser.LogoutEverywhere(ctx, uID)
+	if err != nil {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("error in logging out of every session")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ForgotPassword sends a password reset link, the response is the same whether or not the email is
+// registered so it cannot be used to find out who has an account
+func (h *Handler) ForgotPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace ID")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	var request model.ForgotPasswordRequest
+
+	err := json.NewDecoder(c.Request.Body).Decode(&request)
+	if err != nil {
+		log.Error().Err(err).Str("trace Id :", traceId).Msg("error in decoding")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("error in validating")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	err = h.serviceUser.ForgotPassword(ctx, request.EmailID)
+	if err != nil {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("error in starting password reset")
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "if the email is registered a password reset link has been sent to it"})
+}
+
+// ResetPassword sets a new password with the token from a password reset link
+func (h *Handler) ResetPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace ID")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	var request model.ResetPasswordRequest
+
+	err := json.NewDecoder(c.Request.Body).Decode(&request)
+	if err != nil {
+		log.Error().Err(err).Str("trace Id :", traceId).Msg("error in decoding")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("error in validating")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	err = h.serviceUser.ResetPassword(ctx, request)
+	if errors.Is(err, service.ErrInvalidResetToken) {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("invalid password reset token")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("error in resetting password")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// VerifyEmail marks the email verified with the token from a verification link
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace ID")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	var request model.VerifyEmailRequest
+
+	err := json.NewDecoder(c.Request.Body).Decode(&request)
+	if err != nil {
+		log.Error().Err(err).Str("trace Id :", traceId).Msg("error in decoding")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("error in validating")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	err = h.serviceUser.VerifyEmail(ctx, request.Token)
+	if errors.Is(err, service.ErrInvalidEmailToken) {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("invalid email verification token")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("error in verifying email")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ResendVerification sends the logged in user a new email verification link
+func (h *Handler) ResendVerification(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace ID")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	claims, ok := authentication.ClaimsFromContext(ctx)
+	if !ok {
+		log.Info().Str("trace id : ", traceId).Msg("login first")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	uID, err := claims.UserID()
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user in token")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	err = h.serviceUser.ResendVerification(ctx, uID)
+	if errors.Is(err, service.ErrEmailVerified) {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("email is already verified")
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error ": err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrResendThrottled) {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("email verification resent too often")
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error ": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("error in resending email verification")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "a verification link has been sent to your email"})
+}
+
+// UpdateUserRole lets an admin give a user another role
+func (h *Handler) UpdateUserRole(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	traceId, ok := ctx.Value(middleware.TraceIDKey).(string)
+	if !ok {
+		log.Info().Msg("missing trace ID")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	uID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Str("trace id : ", traceId).Msg("invalid user id")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	var request model.UpdateRole
+
+	err = json.NewDecoder(c.Request.Body).Decode(&request)
+	if err != nil {
+		log.Error().Err(err).Str("trace Id :", traceId).Msg("error in decoding")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("error in validating")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error ": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	userData, err := h.serviceUser.UpdateUserRole(ctx, uint(uID), request.Role)
+	if errors.Is(err, service.ErrUserNotFound) {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("user not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error ": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("trace ID :", traceId).Msg("error in updating user role")
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	c.JSON(http.StatusOK, userData)
 }
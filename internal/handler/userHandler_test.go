@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"errors"
+	"job-portal-api/internal/authentication"
 	"job-portal-api/internal/middleware"
 	"job-portal-api/internal/model"
 	"job-portal-api/internal/service"
@@ -12,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"go.uber.org/mock/gomock"
 	"gopkg.in/go-playground/assert.v1"
 )
@@ -66,6 +68,43 @@ func TestHandler_Signup(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse:   `{"error ":"Bad Request"}`,
 		},
+		{name: "requested role is ignored",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://tests.com", strings.NewReader(`
+				{"username":"soma","emailID":"soma@gmail.com","password":"12345678","role":"company_admin"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().UserSignup(model.UserSignup{UserName: "soma", EmailID: "soma@gmail.com", Password: "12345678"}).
+					Return(model.User{UserName: "soma", EmailID: "soma@gmail.com", Role: model.RoleCandidate}, nil)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"ID":0,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"username":"soma","emailID":"soma@gmail.com","role":"candidate","emailStatus":""}`,
+		},
+		{name: "invalid email",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodGet, "http://tests.com", strings.NewReader(`
+				{"username":"soma","emailID":"soma","password":"12345678"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error ":"Bad Request"}`,
+		},
 		{name: "failure case",
 			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
 				rr := httptest.NewRecorder()
@@ -106,7 +145,7 @@ func TestHandler_Signup(t *testing.T) {
 				return c, rr, ms
 			},
 			expectedStatusCode: http.StatusOK,
-			expectedResponse:   `{"ID":0,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"username":"","emailID":""}`,
+			expectedResponse:   `{"ID":0,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"username":"","emailID":"","role":"","emailStatus":""}`,
 		},
 	}
 	for _, tt := range tests {
@@ -187,7 +226,7 @@ func TestHandler_login(t *testing.T) {
 				mc := gomock.NewController(t)
 				ms := service.NewMockUserService(mc)
 
-				ms.EXPECT().Userlogin(gomock.Any()).Return("", errors.New("error invalid input"))
+				ms.EXPECT().Userlogin(gomock.Any()).Return(model.TokenPair{}, errors.New("error invalid input"))
 
 				return c, rr, ms
 			},
@@ -208,12 +247,12 @@ func TestHandler_login(t *testing.T) {
 				mc := gomock.NewController(t)
 				ms := service.NewMockUserService(mc)
 
-				ms.EXPECT().Userlogin(gomock.Any()).Return("", nil)
+				ms.EXPECT().Userlogin(gomock.Any()).Return(model.TokenPair{AccessToken: "access", RefreshToken: "s1.refresh", ExpiresIn: 900}, nil)
 
 				return c, rr, ms
 			},
 			expectedStatusCode: http.StatusOK,
-			expectedResponse:   `{"token ":""}`,
+			expectedResponse:   `{"expiresIn":900,"refreshToken":"s1.refresh","token ":"access"}`,
 		},
 	}
 	for _, tt := range tests {
@@ -229,3 +268,670 @@ func TestHandler_login(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_Refresh(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.UserService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "missing refresh token",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(`{}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error ":"Bad Request"}`,
+		},
+		{
+			name: "invalid refresh token",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(`{"refreshToken":"s1.old"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().RefreshSession(gomock.Any(), "s1.old").Return(model.TokenPair{}, service.ErrInvalidRefreshToken)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"error ":"Unauthorized"}`,
+		},
+		{
+			name: "failure",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(`{"refreshToken":"s1.old"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().RefreshSession(gomock.Any(), "s1.old").Return(model.TokenPair{}, errors.New("error"))
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error ":"Internal Server Error"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(`{"refreshToken":"s1.old"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().RefreshSession(gomock.Any(), "s1.old").Return(model.TokenPair{AccessToken: "access", RefreshToken: "s1.new", ExpiresIn: 900}, nil)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"expiresIn":900,"refreshToken":"s1.new","token ":"access"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, ms := tt.setup()
+			h := Handler{
+				serviceUser: ms,
+			}
+			h.Refresh(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestHandler_ForgotPassword(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.UserService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "missing email",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(`{}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error ":"Bad Request"}`,
+		},
+		{
+			name: "failure is not reported",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(`{"emailID":"a@b.com"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().ForgotPassword(gomock.Any(), "a@b.com").Return(errors.New("error"))
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusAccepted,
+			expectedResponse:   `{"message":"if the email is registered a password reset link has been sent to it"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(`{"emailID":"a@b.com"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().ForgotPassword(gomock.Any(), "a@b.com").Return(nil)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusAccepted,
+			expectedResponse:   `{"message":"if the email is registered a password reset link has been sent to it"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, ms := tt.setup()
+			h := Handler{
+				serviceUser: ms,
+			}
+			h.ForgotPassword(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestHandler_ResetPassword(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.UserService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "missing password",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(`{"token":"token"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error ":"Bad Request"}`,
+		},
+		{
+			name: "password too short",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(`{"token":"token","password":"short"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error ":"Bad Request"}`,
+		},
+		{
+			name: "invalid token",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(`{"token":"token","password":"new password"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().ResetPassword(gomock.Any(), model.ResetPasswordRequest{Token: "token", Password: "new password"}).Return(service.ErrInvalidResetToken)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error ":"password reset token is invalid or has expired"}`,
+		},
+		{
+			name: "failure",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(`{"token":"token","password":"new password"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().ResetPassword(gomock.Any(), model.ResetPasswordRequest{Token: "token", Password: "new password"}).Return(errors.New("error"))
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error ":"Internal Server Error"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(`{"token":"token","password":"new password"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().ResetPassword(gomock.Any(), model.ResetPasswordRequest{Token: "token", Password: "new password"}).Return(nil)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusNoContent,
+			expectedResponse:   ``,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, ms := tt.setup()
+			h := Handler{
+				serviceUser: ms,
+			}
+			h.ResetPassword(c)
+			//the server writes the status once the handler returns, a status without a body is only set
+			c.Writer.WriteHeaderNow()
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestHandler_VerifyEmail(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.UserService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "missing token",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(`{}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error ":"Bad Request"}`,
+		},
+		{
+			name: "invalid token",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(`{"token":"token"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().VerifyEmail(gomock.Any(), "token").Return(service.ErrInvalidEmailToken)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error ":"email verification token is invalid or has expired"}`,
+		},
+		{
+			name: "failure",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(`{"token":"token"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().VerifyEmail(gomock.Any(), "token").Return(errors.New("error"))
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error ":"Internal Server Error"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(`{"token":"token"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().VerifyEmail(gomock.Any(), "token").Return(nil)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusNoContent,
+			expectedResponse:   ``,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, ms := tt.setup()
+			h := Handler{
+				serviceUser: ms,
+			}
+			h.VerifyEmail(c)
+			//the server writes the status once the handler returns, a status without a body is only set
+			c.Writer.WriteHeaderNow()
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestHandler_ResendVerification(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.UserService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "missing claims",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(``))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"error ":"Unauthorized"}`,
+		},
+		{
+			name: "invalid subject",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(``))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "abc"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"error ":"Unauthorized"}`,
+		},
+		{
+			name: "already verified",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(``))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().ResendVerification(gomock.Any(), uint(1)).Return(service.ErrEmailVerified)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `{"error ":"email is already verified"}`,
+		},
+		{
+			name: "throttled",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(``))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().ResendVerification(gomock.Any(), uint(1)).Return(service.ErrResendThrottled)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedResponse:   `{"error ":"a verification email was sent recently, try again later"}`,
+		},
+		{
+			name: "failure",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(``))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().ResendVerification(gomock.Any(), uint(1)).Return(errors.New("error"))
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error ":"Internal Server Error"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPost, "http://tests.com", strings.NewReader(``))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				ctx = context.WithValue(ctx, authentication.AuthKey, authentication.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "1"}})
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().ResendVerification(gomock.Any(), uint(1)).Return(nil)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusAccepted,
+			expectedResponse:   `{"message":"a verification link has been sent to your email"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, ms := tt.setup()
+			h := Handler{
+				serviceUser: ms,
+			}
+			h.ResendVerification(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
+
+func TestHandler_UpdateUserRole(t *testing.T) {
+	tests := []struct {
+		name               string
+		setup              func() (*gin.Context, *httptest.ResponseRecorder, service.UserService)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "invalid id",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPut, "http://tests.com", strings.NewReader(`{"role":"recruiter"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "abc"})
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error ":"Bad Request"}`,
+		},
+		{
+			name: "unknown role",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPut, "http://tests.com", strings.NewReader(`{"role":"owner"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "2"})
+
+				return c, rr, nil
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error ":"Bad Request"}`,
+		},
+		{
+			name: "user not found",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPut, "http://tests.com", strings.NewReader(`{"role":"recruiter"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "2"})
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().UpdateUserRole(gomock.Any(), uint(2), model.RoleRecruiter).Return(model.User{}, service.ErrUserNotFound)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"error ":"user does not exist"}`,
+		},
+		{
+			name: "failure",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPut, "http://tests.com", strings.NewReader(`{"role":"recruiter"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "2"})
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().UpdateUserRole(gomock.Any(), uint(2), model.RoleRecruiter).Return(model.User{}, errors.New("error"))
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"error ":"Internal Server Error"}`,
+		},
+		{
+			name: "success",
+			setup: func() (*gin.Context, *httptest.ResponseRecorder, service.UserService) {
+				rr := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(rr)
+				httpRequest, _ := http.NewRequest(http.MethodPut, "http://tests.com", strings.NewReader(`{"role":"recruiter"}`))
+				ctx := httpRequest.Context()
+				ctx = context.WithValue(ctx, middleware.TraceIDKey, "1")
+				httpRequest = httpRequest.WithContext(ctx)
+				c.Request = httpRequest
+				c.Params = append(c.Params, gin.Param{Key: "id", Value: "2"})
+
+				mc := gomock.NewController(t)
+				ms := service.NewMockUserService(mc)
+
+				ms.EXPECT().UpdateUserRole(gomock.Any(), uint(2), model.RoleRecruiter).Return(model.User{UserName: "soma", Role: model.RoleRecruiter}, nil)
+
+				return c, rr, ms
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"ID":0,"CreatedAt":"0001-01-01T00:00:00Z","UpdatedAt":"0001-01-01T00:00:00Z","DeletedAt":null,"username":"soma","emailID":"","role":"recruiter","emailStatus":""}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, rr, ms := tt.setup()
+			h := Handler{
+				serviceUser: ms,
+			}
+			h.UpdateUserRole(c)
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"context"
 	"errors"
 	"job-portal-api/internal/authentication"
 	"net/http"
@@ -45,7 +44,26 @@ func (m *Mid) Authentication(next gin.HandlerFunc) gin.HandlerFunc {
 			return
 		}
 
-		ctx = context.WithValue(ctx, authentication.AuthKey, claims)
+		//tokens without an id cannot be revoked so they are not accepted
+		if claims.ID == "" {
+			log.Error().Str("Trace id : ", traceID).Msg("token has no id")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+
+		revoked, err := m.sessions.IsTokenRevoked(ctx, claims.ID)
+		if err != nil {
+			log.Error().Err(err).Str("Trace id : ", traceID).Msg("error in checking token revocation")
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+			return
+		}
+		if revoked {
+			log.Error().Str("Trace id : ", traceID).Str("jti", claims.ID).Msg("token has been revoked")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+
+		ctx = authentication.WithClaims(ctx, claims)
 
 		req := c.Request.WithContext(ctx)
 
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"errors"
+	"job-portal-api/internal/authentication"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// RequireRole allows the request only when the role in the token is one of the given roles,
+// it has to be wrapped inside Authentication so the claims are present in the context
+func (m *Mid) RequireRole(next gin.HandlerFunc, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		ctx := c.Request.Context()
+
+		traceID, ok := ctx.Value(TraceIDKey).(string)
+		if !ok {
+			log.Info().Msg("traceID is not present in the context")
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+			return
+		}
+
+		claims, ok := authentication.ClaimsFromContext(ctx)
+		if !ok {
+			log.Info().Str("trace id : ", traceID).Msg("claims are not present in the context")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+
+		for _, role := range roles {
+			if claims.Role == role {
+				next(c)
+				return
+			}
+		}
+
+		err := errors.New("role is not allowed to access this route")
+		log.Error().Err(err).Str("trace id : ", traceID).Str("role", claims.Role).Send()
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error ": http.StatusText(http.StatusForbidden)})
+	}
+}
+
+// RequireVerifiedEmail allows the request only when the token was issued after the user verified
+// the email, it has to be wrapped inside Authentication so the claims are present in the context
+func (m *Mid) RequireVerifiedEmail(next gin.HandlerFunc) gin.HandlerFunc {
+	if !m.requireVerifiedEmail {
+		return next
+	}
+
+	return func(c *gin.Context) {
+
+		ctx := c.Request.Context()
+
+		traceID, ok := ctx.Value(TraceIDKey).(string)
+		if !ok {
+			log.Info().Msg("traceID is not present in the context")
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error ": http.StatusText(http.StatusInternalServerError)})
+			return
+		}
+
+		claims, ok := authentication.ClaimsFromContext(ctx)
+		if !ok {
+			log.Info().Str("trace id : ", traceID).Msg("claims are not present in the context")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error ": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+
+		if !claims.EmailVerified {
+			log.Info().Str("trace id : ", traceID).Str("user", claims.Subject).Msg("email is not verified")
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error ": "email is not verified"})
+			return
+		}
+
+		next(c)
+	}
+}
@@ -3,26 +3,38 @@ package middleware
 import (
 	"fmt"
 	"job-portal-api/internal/authentication"
+	"job-portal-api/internal/cache"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
 )
 
 type Mid struct {
-	auth authentication.Authenticaton
+	auth     authentication.Authenticaton
+	sessions cache.SessionStore
+	// requireVerifiedEmail turns RequireVerifiedEmail on, without it every user passes
+	requireVerifiedEmail bool
 }
 
 type Middleware interface {
 	Authentication(next gin.HandlerFunc) gin.HandlerFunc
+	RequireRole(next gin.HandlerFunc, roles ...string) gin.HandlerFunc
+	RequireVerifiedEmail(next gin.HandlerFunc) gin.HandlerFunc
 	Log() gin.HandlerFunc
 }
 
-func NewMid(auth authentication.Authenticaton) (Middleware, error) {
+func NewMid(auth authentication.Authenticaton, sessions cache.SessionStore, requireVerifiedEmail bool) (Middleware, error) {
 	if auth == nil {
 		log.Info().Msg("authencatiomn is nil")
 		return nil, fmt.Errorf("error authentication is nil")
 	}
+	if sessions == nil {
+		log.Info().Msg("session store is nil")
+		return nil, fmt.Errorf("error session store is nil")
+	}
 	return &Mid{
-		auth: auth,
+		auth:                 auth,
+		sessions:             sessions,
+		requireVerifiedEmail: requireVerifiedEmail,
 	}, nil
 }
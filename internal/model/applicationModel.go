@@ -0,0 +1,146 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// states an application moves through during hiring
+const (
+	ApplicationApplied      = "applied"
+	ApplicationScreened     = "screened"
+	ApplicationInterviewing = "interviewing"
+	ApplicationOffered      = "offered"
+	ApplicationHired        = "hired"
+	ApplicationRejected     = "rejected"
+	ApplicationWithdrawn    = "withdrawn"
+)
+
+// Application is a candidate's application to a job, a user has at most one open application per job
+// and can apply again once theirs is withdrawn or rejected
+type Application struct {
+	gorm.Model
+	Jid      uint         `json:"jid" gorm:"index;uniqueIndex:idx_open_application,where:user_id <> 0 AND status <> 'withdrawn' AND status <> 'rejected' AND deleted_at IS NULL"`
+	UserID   uint         `json:"userID" gorm:"index;uniqueIndex:idx_open_application"`
+	Name     string       `json:"name"`
+	Age      string       `json:"age"`
+	Details  Requestfield `json:"job_application" gorm:"type:jsonb"`
+	Accepted bool         `json:"accepted"`
+	Score    int          `json:"score"`
+	Report   MatchReport  `json:"report" gorm:"type:jsonb"`
+	Status   string       `json:"status" gorm:"default:applied"`
+}
+
+type MatchResult struct {
+	Score    int         `json:"score"`
+	Accepted bool        `json:"accepted"`
+	Report   MatchReport `json:"report"`
+}
+
+// MatchReport explains a match decision criterion by criterion
+type MatchReport []CriterionResult
+
+// CriterionResult holds what the candidate provided and what the job asks for on one criterion,
+// ranges are reported as a Range and everything else as the list of ids
+type CriterionResult struct {
+	Criterion string      `json:"criterion"`
+	Matched   bool        `json:"matched"`
+	MustHave  bool        `json:"mustHave"`
+	Weight    int         `json:"weight"`
+	Provided  interface{} `json:"provided"`
+	Required  interface{} `json:"required"`
+}
+
+type Range struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// outcome of every item of a processed batch of applications
+const (
+	OutcomeAccepted      = "accepted"
+	OutcomeInvalid       = "invalid"
+	OutcomeRejected      = "rejected"
+	OutcomeInvalidJob    = "invalid_job"
+	OutcomeJobClosed     = "job_closed"
+	OutcomeDuplicate     = "duplicate"
+	OutcomeForbidden     = "forbidden"
+	OutcomeInternalError = "internal_error"
+)
+
+// ProcessedApplication is the result for one item of a batch, Index is its position in the request
+type ProcessedApplication struct {
+	Index         int                `json:"index"`
+	Outcome       string             `json:"outcome"`
+	Error         string             `json:"error,omitempty"`
+	ApplicationID uint               `json:"applicationID"`
+	Application   NewUserApplication `json:"application"`
+	Score         int                `json:"score"`
+	Accepted      bool               `json:"accepted"`
+	Report        MatchReport        `json:"report"`
+}
+
+type ApplicationStatusHistory struct {
+	gorm.Model
+	ApplicationID uint      `json:"applicationID" gorm:"index"`
+	FromStatus    string    `json:"fromStatus"`
+	ToStatus      string    `json:"toStatus"`
+	ChangedBy     uint      `json:"changedBy"`
+	ChangedAt     time.Time `json:"changedAt"`
+	Note          string    `json:"note"`
+}
+
+type ApplicationStatusUpdate struct {
+	Status string `json:"status" validate:"required,oneof=applied screened interviewing offered hired rejected withdrawn"`
+	Note   string `json:"note"`
+}
+
+// Value stores the application details as json in the database
+func (r Requestfield) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// Scan reads the application details back from the json stored in the database
+func (r *Requestfield) Scan(value interface{}) error {
+	return scanJSON(value, r)
+}
+
+// Value stores the match criteria as json in the database
+func (m MatchCriteria) Value() (driver.Value, error) {
+	return json.Marshal(m)
+}
+
+// Scan reads the match criteria back from the database, jobs created before
+// criteria existed have no value and keep the zero criteria
+func (m *MatchCriteria) Scan(value interface{}) error {
+	return scanJSON(value, m)
+}
+
+// Value stores the match report as json in the database
+func (m MatchReport) Value() (driver.Value, error) {
+	return json.Marshal(m)
+}
+
+// Scan reads the match report back from the json stored in the database
+func (m *MatchReport) Scan(value interface{}) error {
+	return scanJSON(value, m)
+}
+
+func scanJSON(value interface{}, dest interface{}) error {
+	if value == nil {
+		return nil
+	}
+	data, ok := value.([]byte)
+	if !ok {
+		str, ok := value.(string)
+		if !ok {
+			return errors.New("invalid type for json column")
+		}
+		data = []byte(str)
+	}
+	return json.Unmarshal(data, dest)
+}
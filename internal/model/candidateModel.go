@@ -0,0 +1,54 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+
+	"gorm.io/gorm"
+)
+
+// CandidateProfile is what a candidate applies to jobs with, every user has at most one
+type CandidateProfile struct {
+	gorm.Model
+	UserID          uint              `json:"userID" gorm:"uniqueIndex"`
+	Name            string            `json:"name"`
+	Age             string            `json:"age"`
+	Headline        string            `json:"headline"`
+	Experience      int               `json:"experience"`
+	NoticePeriod    int               `json:"noticePeriod"`
+	ExpectedSalary  int               `json:"expectedSalary"`
+	WorkMode        WorkModes         `json:"workMode" gorm:"type:jsonb"`
+	TechnologyStack []TechnologyStack `json:"skills" gorm:"many2many:candidate_techstack;"`
+	Qualifications  []Qualification   `json:"qualifications" gorm:"many2many:candidate_qualification;"`
+	Location        []Location        `json:"location" gorm:"many2many:candidate_location;"`
+	Shift           []Shift           `json:"shifts" gorm:"many2many:candidate_shift;"`
+	Jobtype         []JobType         `json:"jobtype" gorm:"many2many:candidate_job_type;"`
+}
+
+// WorkModes are the work modes a candidate is open to
+type WorkModes []string
+
+type NewCandidateProfile struct {
+	Name            string   `json:"name" validate:"required"`
+	Age             string   `json:"age" validate:"required"`
+	Headline        string   `json:"headline"`
+	Experience      int      `json:"experience" validate:"min=0"`
+	NoticePeriod    int      `json:"noticePeriod" validate:"min=0"`
+	ExpectedSalary  int      `json:"expectedSalary" validate:"min=0"`
+	WorkMode        []string `json:"workMode" validate:"dive,oneof=remote hybrid onsite"`
+	TechnologyStack []uint   `json:"technologyStack"`
+	Qualifications  []uint   `json:"qualifications"`
+	Location        []uint   `json:"location"`
+	Shift           []uint   `json:"shifts"`
+	Jobtype         []uint   `json:"jobtype"`
+}
+
+// Value stores the work modes as json in the database
+func (w WorkModes) Value() (driver.Value, error) {
+	return json.Marshal(w)
+}
+
+// Scan reads the work modes back from the json stored in the database
+func (w *WorkModes) Scan(value interface{}) error {
+	return scanJSON(value, w)
+}
@@ -14,3 +14,34 @@ type AddCompany struct {
 	Address     string `json:"address"`
 	Domain      string `json:"domain"`
 }
+
+// UpdateCompany holds the fields of a company update, nil fields are left as they are
+type UpdateCompany struct {
+	CompanyName *string `json:"companyName" validate:"omitempty,min=1"`
+	Address     *string `json:"address"`
+	Domain      *string `json:"domain"`
+}
+
+// roles and states of a user inside a company
+const (
+	MemberRoleOwner     = "owner"
+	MemberRoleRecruiter = "recruiter"
+	MemberRoleViewer    = "viewer"
+
+	MemberStatusInvited = "invited"
+	MemberStatusActive  = "active"
+)
+
+type CompanyMember struct {
+	gorm.Model
+	CompanyID uint   `json:"companyID" gorm:"uniqueIndex:idx_company_member"`
+	UserID    uint   `json:"userID" gorm:"uniqueIndex:idx_company_member"`
+	Role      string `json:"role"`
+	Status    string `json:"status"`
+	InvitedBy uint   `json:"invitedBy"`
+}
+
+type InviteMember struct {
+	EmailID string `json:"emailID" validate:"required"`
+	Role    string `json:"role" validate:"required,oneof=owner recruiter viewer"`
+}
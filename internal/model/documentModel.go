@@ -0,0 +1,65 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// kinds of documents a candidate can upload
+const (
+	DocumentResume      = "resume"
+	DocumentCoverLetter = "cover_letter"
+)
+
+// content types of the accepted documents
+const (
+	ContentTypePDF  = "application/pdf"
+	ContentTypeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+)
+
+// Document is a file uploaded by a candidate, it belongs to their profile when it is not
+// attached to an application
+type Document struct {
+	gorm.Model
+	UserID        uint           `json:"userID" gorm:"index"`
+	ApplicationID *uint          `json:"applicationID" gorm:"index"`
+	Kind          string         `json:"kind"`
+	FileName      string         `json:"fileName"`
+	ContentType   string         `json:"contentType"`
+	Size          int64          `json:"size"`
+	StorageKey    string         `json:"-"`
+	Skills        DetectedSkills `json:"detectedSkills" gorm:"type:jsonb"`
+}
+
+// DetectedSkills holds the technology stack and qualification ids whose names were found in a resume
+type DetectedSkills struct {
+	TechnologyStack []uint `json:"technologyStack"`
+	Qualifications  []uint `json:"qualifications"`
+}
+
+type NewDocument struct {
+	Kind          string `json:"kind" validate:"required,oneof=resume cover_letter"`
+	ApplicationID *uint  `json:"applicationID"`
+	FileName      string `json:"fileName" validate:"required"`
+	Size          int64  `json:"size" validate:"gt=0"`
+}
+
+// DocumentLink is a document along with a signed link to download it until ExpiresAt
+type DocumentLink struct {
+	Document
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Value stores the detected skills as json in the database
+func (d DetectedSkills) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+// Scan reads the detected skills back from the database, documents uploaded before detection existed have none
+func (d *DetectedSkills) Scan(value interface{}) error {
+	return scanJSON(value, d)
+}
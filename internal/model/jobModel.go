@@ -1,6 +1,10 @@
 package model
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Job struct {
 	gorm.Model
@@ -17,56 +21,262 @@ type Job struct {
 	Qualifications  []Qualification   `json:"qualifications" gorm:"many2many:job_qualification;"`
 	Shift           []Shift           `json:"shifts" gorm:"many2many:job_shift;" `
 	Jobtype         []JobType         `json:"jobtype" gorm:"many2many:job_type;"`
+	Criteria        MatchCriteria     `json:"matchCriteria" gorm:"type:jsonb"`
+	Closed          bool              `json:"closed"`
+	MinSalary       int               `json:"min_salary"`
+	MaxSalary       int               `json:"max_salary"`
+	Currency        string            `json:"currency"`
+	PayPeriod       string            `json:"pay_period"`
+	WorkMode        string            `json:"work_mode" gorm:"index"`
+	Status          string            `json:"status" gorm:"default:published;index"`
+	ExpiresAt       *time.Time        `json:"expiresAt"`
+	//search document of the job, it is maintained by the repository and never read or written through the model
+	SearchVector string `json:"-" gorm:"->:false;type:tsvector;index:idx_jobs_search,type:gin"`
+}
+
+// states of a job posting, drafts and archived jobs are only seen by the company and a published job
+// is listed until it expires
+const (
+	JobStatusDraft     = "draft"
+	JobStatusPublished = "published"
+	JobStatusExpired   = "expired"
+	JobStatusArchived  = "archived"
+)
+
+// Listed tells whether the job is shown in listings at the given time, a published job past its expiry
+// is not listed even before it is marked expired
+func (j Job) Listed(now time.Time) bool {
+	if j.Status != JobStatusPublished {
+		return false
+	}
+	return j.ExpiresAt == nil || j.ExpiresAt.After(now)
+}
+
+// criteria an application is matched on
+const (
+	CriterionNoticePeriod    = "notice_period"
+	CriterionExperience      = "experience"
+	CriterionLocation        = "location"
+	CriterionTechnologyStack = "technology_stack"
+	CriterionQualifications  = "qualifications"
+	CriterionShift           = "shift"
+	CriterionJobType         = "job_type"
+	CriterionSalary          = "salary"
+	CriterionWorkMode        = "work_mode"
+)
+
+// periods a salary is paid for
+const (
+	PayPeriodHour  = "hour"
+	PayPeriodMonth = "month"
+	PayPeriodYear  = "year"
+)
+
+// where the work of a job is done
+const (
+	WorkModeRemote = "remote"
+	WorkModeHybrid = "hybrid"
+	WorkModeOnsite = "onsite"
+)
+
+// MatchCriteria configures how applications are scored for a job, weights are relative to each other,
+// an application missing any must have criterion is rejected whatever its score. Salary and work mode
+// are only matched when they are given a weight or are must have
+type MatchCriteria struct {
+	Weights  map[string]int `json:"weights" validate:"dive,keys,oneof=notice_period experience location technology_stack qualifications shift job_type salary work_mode,endkeys,min=0"`
+	MustHave []string       `json:"mustHave" validate:"dive,oneof=notice_period experience location technology_stack qualifications shift job_type salary work_mode"`
+	MinScore int            `json:"minScore" validate:"min=0,max=100"`
+}
+
+// kinds of values jobs and applications refer to by id
+const (
+	TaxonomyLocation        = "location"
+	TaxonomyTechnologyStack = "technology_stack"
+	TaxonomyQualification   = "qualification"
+	TaxonomyShift           = "shift"
+	TaxonomyJobType         = "job_type"
+)
+
+// Taxonomy is the common shape of a location, skill, qualification, shift or job type,
+// retired values stay on the jobs using them but cannot be picked for new ones
+type Taxonomy struct {
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Retired bool   `json:"retired"`
+}
+
+type TaxonomyName struct {
+	Name string `json:"name" validate:"required"`
 }
 
 type JobType struct {
 	gorm.Model
-	JobTypeName string `json:"jobtype"`
+	JobTypeName string `json:"jobtype" gorm:"uniqueIndex"`
+	Retired     bool   `json:"retired"`
 }
 
 type Location struct {
 	gorm.Model
-	PlaceName string `json:"place_name"`
+	PlaceName string `json:"place_name" gorm:"uniqueIndex"`
+	Retired   bool   `json:"retired"`
 }
 
 type TechnologyStack struct {
 	gorm.Model
-	StackName string `json:"stack_name"`
+	StackName string `json:"stack_name" gorm:"uniqueIndex"`
+	Retired   bool   `json:"retired"`
 }
 
 type Qualification struct {
 	gorm.Model
-	QualificationRequired string `json:"qualification_required"`
+	QualificationRequired string `json:"qualification_required" gorm:"uniqueIndex"`
+	Retired               bool   `json:"retired"`
 }
 
 type Shift struct {
 	gorm.Model
-	ShiftType string `json:"shift_type"`
+	ShiftType string `json:"shift_type" gorm:"uniqueIndex"`
+	Retired   bool   `json:"retired"`
 }
 
 type NewJobs struct {
-	Jobname         string `json:"jobName" validate:"required"`
-	MinNoticePeriod int    `json:"minNoticePeriod" validate:"required"`
-	MaxNoticePeriod uint   `json:"maxNoticePeriod" validate:"required"`
-	Location        []uint `json:"location" `
-	TechnologyStack []uint `json:"technologyStack" `
-	Description     string `json:"description" validate:"required"`
-	MinExperience   int    `json:"minExperience" validate:"required"`
-	MaxExperience   uint   `json:"maxExperience" validate:"required"`
-	Qualifications  []uint `json:"qualifications"`
-	Shift           []uint `json:"shifts"`
-	Jobtype         []uint `json:"jobtype"`
+	Jobname         string         `json:"jobName" validate:"required"`
+	MinNoticePeriod int            `json:"minNoticePeriod" validate:"required"`
+	MaxNoticePeriod uint           `json:"maxNoticePeriod" validate:"required"`
+	Location        []uint         `json:"location" `
+	TechnologyStack []uint         `json:"technologyStack" `
+	Description     string         `json:"description" validate:"required"`
+	MinExperience   int            `json:"minExperience" validate:"required"`
+	MaxExperience   uint           `json:"maxExperience" validate:"required"`
+	Qualifications  []uint         `json:"qualifications"`
+	Shift           []uint         `json:"shifts"`
+	Jobtype         []uint         `json:"jobtype"`
+	Criteria        *MatchCriteria `json:"matchCriteria"`
+	MinSalary       int            `json:"minSalary" validate:"min=0"`
+	MaxSalary       int            `json:"maxSalary" validate:"omitempty,gtefield=MinSalary"`
+	Currency        string         `json:"currency" validate:"required_with=MinSalary MaxSalary,omitempty,iso4217"`
+	PayPeriod       string         `json:"payPeriod" validate:"required_with=MinSalary MaxSalary,omitempty,oneof=hour month year"`
+	WorkMode        string         `json:"workMode" validate:"omitempty,oneof=remote hybrid onsite"`
+	Draft           bool           `json:"draft"`
+	ExpiresAt       *time.Time     `json:"expiresAt"`
+}
+
+// UpdateJob holds the fields of a partial job update, nil fields are left as they are
+// and a non nil list replaces the existing one
+type UpdateJob struct {
+	Jobname         *string        `json:"jobName" validate:"omitempty,min=1"`
+	MinNoticePeriod *int           `json:"minNoticePeriod" validate:"omitempty,min=0"`
+	MaxNoticePeriod *uint          `json:"maxNoticePeriod"`
+	Location        *[]uint        `json:"location"`
+	TechnologyStack *[]uint        `json:"technologyStack"`
+	Description     *string        `json:"description" validate:"omitempty,min=1"`
+	MinExperience   *int           `json:"minExperience" validate:"omitempty,min=0"`
+	MaxExperience   *uint          `json:"maxExperience"`
+	Qualifications  *[]uint        `json:"qualifications"`
+	Shift           *[]uint        `json:"shifts"`
+	Jobtype         *[]uint        `json:"jobtype"`
+	Criteria        *MatchCriteria `json:"matchCriteria"`
+	MinSalary       *int           `json:"minSalary" validate:"omitempty,min=0"`
+	MaxSalary       *int           `json:"maxSalary" validate:"omitempty,min=0"`
+	Currency        *string        `json:"currency" validate:"omitempty,iso4217"`
+	PayPeriod       *string        `json:"payPeriod" validate:"omitempty,oneof=hour month year"`
+	WorkMode        *string        `json:"workMode" validate:"omitempty,oneof=remote hybrid onsite"`
+	ExpiresAt       *time.Time     `json:"expiresAt"`
+}
+
+// sort orders of a job listing
+const (
+	JobSortNewest       = "newest"
+	JobSortOldest       = "oldest"
+	JobSortExperience   = "experience"
+	JobSortNoticePeriod = "notice_period"
+	JobSortName         = "name"
+	JobSortSalary       = "salary"
+)
+
+// JobFilter is read from the query of a job listing, list filters match jobs having any of the given ids
+// and the ranges match jobs whose own range overlaps them, a minimum salary matches jobs paying up to at least it or open-ended
+type JobFilter struct {
+	Page            int        `form:"page" validate:"omitempty,min=1"`
+	PageSize        int        `form:"page_size" validate:"omitempty,min=1,max=100"`
+	CompanyID       uint       `form:"company_id"`
+	Location        []uint     `form:"location"`
+	TechnologyStack []uint     `form:"technology_stack"`
+	Jobtype         []uint     `form:"job_type"`
+	Shift           []uint     `form:"shift"`
+	MinExperience   *int       `form:"min_experience" validate:"omitempty,min=0"`
+	MaxExperience   *int       `form:"max_experience" validate:"omitempty,min=0"`
+	MinNoticePeriod *int       `form:"min_notice_period" validate:"omitempty,min=0"`
+	MaxNoticePeriod *int       `form:"max_notice_period" validate:"omitempty,min=0"`
+	PostedSince     *time.Time `form:"posted_since" time_format:"2006-01-02" time_utc:"1"`
+	MinSalary       *int       `form:"min_salary" validate:"omitempty,min=0"`
+	Currency        string     `form:"currency" validate:"omitempty,iso4217"`
+	PayPeriod       string     `form:"pay_period" validate:"omitempty,oneof=hour month year"`
+	WorkMode        []string   `form:"work_mode" validate:"dive,oneof=remote hybrid onsite"`
+	Sort            string     `form:"sort" validate:"omitempty,oneof=newest oldest experience notice_period name salary"`
+}
+
+// JobList is one page of a job listing along with the number of jobs matching the filter
+type JobList struct {
+	Jobs     []Job `json:"jobs"`
+	Total    int64 `json:"total"`
+	Page     int   `json:"page"`
+	PageSize int   `json:"pageSize"`
+}
+
+// JobSearch is a keyword search over jobs which can be narrowed with the filters of a listing,
+// results are ordered by relevance so the sort of the filter is not used
+type JobSearch struct {
+	Query string `form:"q" validate:"required"`
+	JobFilter
+}
+
+// JobSearchResult is a matching job with its relevance and a highlighted part of its description
+type JobSearchResult struct {
+	Job     Job     `json:"job"`
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+type JobSearchList struct {
+	Results  []JobSearchResult `json:"results"`
+	Total    int64             `json:"total"`
+	Page     int               `json:"page"`
+	PageSize int               `json:"pageSize"`
+}
+
+// JobStats are counts kept per job for reporting, they are refreshed in the background so they may lag
+// behind the applications
+type JobStats struct {
+	JobID        uint      `json:"jobID" gorm:"primaryKey;autoIncrement:false"`
+	Applications int64     `json:"applications"`
+	Accepted     int64     `json:"accepted"`
+	Hired        int64     `json:"hired"`
+	RefreshedAt  time.Time `json:"refreshedAt"`
+}
+
+// InvalidReferences lists what a job or a profile refers to that does not exist or is retired
+type InvalidReferences struct {
+	CompanyID       uint   `json:"companyID,omitempty"`
+	Location        []uint `json:"location,omitempty"`
+	TechnologyStack []uint `json:"technologyStack,omitempty"`
+	Qualifications  []uint `json:"qualifications,omitempty"`
+	Shift           []uint `json:"shifts,omitempty"`
+	Jobtype         []uint `json:"jobtype,omitempty"`
 }
 
 type Response struct {
 	Id uint `json:"id"`
 }
 
+// NewUserApplication is a single application, UserID is only kept for a candidate applying for
+// themselves and ignored in a recruiter's batch
 type NewUserApplication struct {
-	Name string       `json:"name" validate:"required"`
-	Age  string       `json:"age" validate:"required"`
-	Jid  uint         `json:"jid" validate:"required"`
-	Jobs Requestfield `json:"job_application"`
+	Name   string       `json:"name" validate:"required"`
+	Age    string       `json:"age" validate:"required"`
+	Jid    uint         `json:"jid" validate:"required"`
+	UserID uint         `json:"userID"`
+	Jobs   Requestfield `json:"job_application"`
 }
 
 type Requestfield struct {
@@ -77,4 +287,7 @@ type Requestfield struct {
 	Qualifications  []uint `json:"qualifications"`
 	Shift           []uint `json:"shifts"`
 	Jobtype         []uint `json:"jobtype"`
+	//expected salary in the currency and pay period of the job
+	ExpectedSalary int      `json:"expectedSalary"`
+	WorkMode       []string `json:"workMode" validate:"dive,oneof=remote hybrid onsite"`
 }
@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// Session is a login of a user kept server side, only the hash of its current refresh token is
+// stored along with the id of the last access token handed out for it
+type Session struct {
+	ID              string    `json:"id"`
+	UserID          uint      `json:"userID"`
+	Role            string    `json:"role"`
+	EmailVerified   bool      `json:"emailVerified"`
+	RefreshHash     string    `json:"refreshHash"`
+	AccessJTI       string    `json:"accessJTI"`
+	AccessExpiresAt time.Time `json:"accessExpiresAt"`
+}
+
+type TokenPair struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int    `json:"expiresIn"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" validate:"required"`
+}
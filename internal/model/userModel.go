@@ -1,21 +1,84 @@
 package model
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// roles a user can hold on the platform
+const (
+	RoleCandidate    = "candidate"
+	RoleRecruiter    = "recruiter"
+	RoleCompanyAdmin = "company_admin"
+	RoleAdmin        = "admin"
+)
+
+// states of the email address of a user, accounts start pending until the address is verified
+const (
+	EmailStatusPending  = "pending"
+	EmailStatusVerified = "verified"
+)
 
 type UserSignup struct {
 	UserName string `json:"username" validate:"required"`
-	EmailID  string `json:"emailID" validate:"required"`
+	EmailID  string `json:"emailID" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
 }
 
+// UpdateRole is the role an admin gives a user, signup always creates candidates
+type UpdateRole struct {
+	Role string `json:"role" validate:"required,oneof=candidate recruiter company_admin admin"`
+}
+
 type User struct {
 	gorm.Model
 	UserName string `json:"username"`
 	EmailID  string `json:"emailID" gorm:"unique"`
 	Password string `json:"-"`
+	Role     string `json:"role" gorm:"default:candidate"`
+	// EmailStatus is pending until the user opens the verification link sent at signup
+	EmailStatus     string     `json:"emailStatus" gorm:"default:pending"`
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt,omitempty"`
+}
+
+// EmailVerified reports whether the user has verified the email address
+func (u User) EmailVerified() bool {
+	return u.EmailStatus == EmailStatusVerified
 }
 
 type UserLogin struct {
 	EmailID  string `json:"emailID" validate:"required"`
 	Password string `json:"password" validate:"required"`
 }
+
+// PasswordReset is a single use token to set a new password, only the hash of the token is stored
+type PasswordReset struct {
+	gorm.Model
+	UserID    uint   `gorm:"index"`
+	TokenHash string `gorm:"uniqueIndex"`
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+type ForgotPasswordRequest struct {
+	EmailID string `json:"emailID" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+// EmailVerification is a single use token proving the user reads the address, only its hash is stored
+type EmailVerification struct {
+	gorm.Model
+	UserID    uint   `gorm:"index"`
+	TokenHash string `gorm:"uniqueIndex"`
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -0,0 +1,86 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Message is a plain text message sent to a single recipient
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+//go:generate mockgen -source=notifier.go -destination=notifier_mock.go -package=notifier
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// LogNotifier writes the messages to the log instead of delivering them, it is used when no mail
+// server is configured so the links can still be picked up during development
+type LogNotifier struct{}
+
+func NewLogNotifier() Notifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, msg Message) error {
+	log.Info().Str("to", msg.To).Str("subject", msg.Subject).Str("body", msg.Body).Msg("notification")
+	return nil
+}
+
+// SMTPNotifier delivers the messages as mails through an smtp server
+type SMTPNotifier struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPNotifier returns a notifier sending mails from the given address, the server is only
+// authenticated against when a username is given
+func NewSMTPNotifier(addr string, from string, username string, password string) (Notifier, error) {
+	if addr == "" || from == "" {
+		return nil, errors.New("smtp address and sender cannot be empty")
+	}
+
+	var auth smtp.Auth
+	if username != "" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid smtp address : %w", err)
+		}
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPNotifier{
+		addr: addr,
+		from: from,
+		auth: auth,
+	}, nil
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, msg Message) error {
+	//the recipient and subject come from our own data but are checked so they cannot add headers
+	if strings.ContainsAny(msg.To, "\r\n") || strings.ContainsAny(msg.Subject, "\r\n") {
+		return errors.New("invalid message header")
+	}
+
+	body := "From: " + n.from + "\r\n" +
+		"To: " + msg.To + "\r\n" +
+		"Subject: " + msg.Subject + "\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"\r\n" + msg.Body
+
+	err := smtp.SendMail(n.addr, n.auth, n.from, []string{msg.To}, []byte(body))
+	if err != nil {
+		return fmt.Errorf("error in sending mail : %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNewSMTPNotifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		addr     string
+		from     string
+		username string
+		wantErr  bool
+	}{
+		{name: "missing address", from: "jobs@example.com", wantErr: true},
+		{name: "missing sender", addr: "localhost:25", wantErr: true},
+		{name: "authentication needs a host", addr: "localhost", from: "jobs@example.com", username: "jobs", wantErr: true},
+		{name: "without authentication", addr: "localhost:25", from: "jobs@example.com"},
+		{name: "with authentication", addr: "localhost:587", from: "jobs@example.com", username: "jobs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSMTPNotifier(tt.addr, tt.from, tt.username, "secret")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSMTPNotifier() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSMTPNotifier_Notify(t *testing.T) {
+	n, _ := NewSMTPNotifier("localhost:25", "jobs@example.com", "", "")
+
+	//headers are refused before anything is sent so no server is needed
+	err := n.Notify(context.Background(), Message{To: "a@b.com\r\nBcc: c@d.com", Subject: "subject", Body: "body"})
+	if err == nil {
+		t.Errorf("SMTPNotifier.Notify() accepted a recipient adding a header")
+	}
+
+	err = n.Notify(context.Background(), Message{To: "a@b.com", Subject: "subject\nBcc: c@d.com", Body: "body"})
+	if err == nil {
+		t.Errorf("SMTPNotifier.Notify() accepted a subject adding a header")
+	}
+}
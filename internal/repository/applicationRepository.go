@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"errors"
+	"job-portal-api/internal/model"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=applicationRepository.go -destination=applicationRepository_mock.go -package=repository
+type ApplicationRepository interface {
+	CreateApplication(application model.Application) (model.Application, error)
+	GetApplicationsByJobID(jID uint) ([]model.Application, error)
+	GetApplicationsByUserID(uID uint) ([]model.Application, error)
+	GetApplicationByID(aID uint) (model.Application, error)
+	UpdateApplicationStatus(application model.Application, history model.ApplicationStatusHistory) (model.Application, error)
+	GetStatusHistory(aID uint) ([]model.ApplicationStatusHistory, error)
+}
+
+func NewApplicationRepo(db *gorm.DB) (ApplicationRepository, error) {
+	if db == nil {
+		log.Info().Msg("database cannot be nil")
+		return nil, errors.New("database cannot be nil")
+	}
+	return &Repo{
+		db: db,
+	}, nil
+}
+
+func (r *Repo) CreateApplication(application model.Application) (model.Application, error) {
+
+	//the initial status is recorded as the first entry of the history
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		output := tx.Create(&application)
+		if output.Error != nil {
+			return output.Error
+		}
+
+		history := model.ApplicationStatusHistory{
+			ApplicationID: application.ID,
+			ToStatus:      application.Status,
+			ChangedAt:     application.CreatedAt,
+		}
+		return tx.Create(&history).Error
+	})
+
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return model.Application{}, ErrDuplicate
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error in creating application")
+		return model.Application{}, errors.New("could not create application")
+	}
+
+	return application, nil
+}
+
+func (r *Repo) GetApplicationsByJobID(jID uint) ([]model.Application, error) {
+
+	var applications []model.Application
+
+	output := r.db.Where("jid = ?", jID).Order("created_at desc").Find(&applications)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error while fetching applications")
+		return nil, errors.New("error while fetching applications")
+	}
+
+	return applications, nil
+}
+
+func (r *Repo) GetApplicationsByUserID(uID uint) ([]model.Application, error) {
+
+	var applications []model.Application
+
+	output := r.db.Where("user_id = ?", uID).Order("created_at desc").Find(&applications)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error while fetching applications of the user")
+		return nil, errors.New("error while fetching applications")
+	}
+
+	return applications, nil
+}
+
+func (r *Repo) GetApplicationByID(aID uint) (model.Application, error) {
+
+	var application model.Application
+
+	output := r.db.Where("id = ?", aID).First(&application)
+	if errors.Is(output.Error, gorm.ErrRecordNotFound) {
+		log.Error().Err(output.Error).Msg("error application id does not exists")
+		return model.Application{}, ErrNotFound
+	}
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error application id does not exists")
+		return model.Application{}, errors.New("application does not exists")
+	}
+
+	return application, nil
+}
+
+// UpdateApplicationStatus moves the application and records the move, ErrNotFound is returned when its
+// status is no longer the one the move starts from
+func (r *Repo) UpdateApplicationStatus(application model.Application, history model.ApplicationStatusHistory) (model.Application, error) {
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		output := tx.Model(&application).Where("status = ?", history.FromStatus).Update("status", application.Status)
+		if output.Error != nil {
+			return output.Error
+		}
+		if output.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return tx.Create(&history).Error
+	})
+	if errors.Is(err, ErrNotFound) {
+		return model.Application{}, ErrNotFound
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error in updating application status")
+		return model.Application{}, errors.New("could not update application status")
+	}
+
+	return application, nil
+}
+
+func (r *Repo) GetStatusHistory(aID uint) ([]model.ApplicationStatusHistory, error) {
+
+	var history []model.ApplicationStatusHistory
+
+	output := r.db.Where("application_id = ?", aID).Order("changed_at asc").Find(&history)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error while fetching application history")
+		return nil, errors.New("error while fetching application history")
+	}
+
+	return history, nil
+}
@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: applicationRepository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=applicationRepository.go -destination=applicationRepository_mock.go -package=repository
+//
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	model "job-portal-api/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockApplicationRepository is a mock of ApplicationRepository interface.
+type MockApplicationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationRepositoryMockRecorder
+}
+
+// MockApplicationRepositoryMockRecorder is the mock recorder for MockApplicationRepository.
+type MockApplicationRepositoryMockRecorder struct {
+	mock *MockApplicationRepository
+}
+
+// NewMockApplicationRepository creates a new mock instance.
+func NewMockApplicationRepository(ctrl *gomock.Controller) *MockApplicationRepository {
+	mock := &MockApplicationRepository{ctrl: ctrl}
+	mock.recorder = &MockApplicationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationRepository) EXPECT() *MockApplicationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateApplication mocks base method.
+func (m *MockApplicationRepository) CreateApplication(application model.Application) (model.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApplication", application)
+	ret0, _ := ret[0].(model.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateApplication indicates an expected call of CreateApplication.
+func (mr *MockApplicationRepositoryMockRecorder) CreateApplication(application any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApplication", reflect.TypeOf((*MockApplicationRepository)(nil).CreateApplication), application)
+}
+
+// GetApplicationByID mocks base method.
+func (m *MockApplicationRepository) GetApplicationByID(aID uint) (model.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicationByID", aID)
+	ret0, _ := ret[0].(model.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicationByID indicates an expected call of GetApplicationByID.
+func (mr *MockApplicationRepositoryMockRecorder) GetApplicationByID(aID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationByID", reflect.TypeOf((*MockApplicationRepository)(nil).GetApplicationByID), aID)
+}
+
+// GetApplicationsByJobID mocks base method.
+func (m *MockApplicationRepository) GetApplicationsByJobID(jID uint) ([]model.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicationsByJobID", jID)
+	ret0, _ := ret[0].([]model.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicationsByJobID indicates an expected call of GetApplicationsByJobID.
+func (mr *MockApplicationRepositoryMockRecorder) GetApplicationsByJobID(jID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationsByJobID", reflect.TypeOf((*MockApplicationRepository)(nil).GetApplicationsByJobID), jID)
+}
+
+// GetApplicationsByUserID mocks base method.
+func (m *MockApplicationRepository) GetApplicationsByUserID(uID uint) ([]model.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicationsByUserID", uID)
+	ret0, _ := ret[0].([]model.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicationsByUserID indicates an expected call of GetApplicationsByUserID.
+func (mr *MockApplicationRepositoryMockRecorder) GetApplicationsByUserID(uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationsByUserID", reflect.TypeOf((*MockApplicationRepository)(nil).GetApplicationsByUserID), uID)
+}
+
+// GetStatusHistory mocks base method.
+func (m *MockApplicationRepository) GetStatusHistory(aID uint) ([]model.ApplicationStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusHistory", aID)
+	ret0, _ := ret[0].([]model.ApplicationStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusHistory indicates an expected call of GetStatusHistory.
+func (mr *MockApplicationRepositoryMockRecorder) GetStatusHistory(aID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusHistory", reflect.TypeOf((*MockApplicationRepository)(nil).GetStatusHistory), aID)
+}
+
+// UpdateApplicationStatus mocks base method.
+func (m *MockApplicationRepository) UpdateApplicationStatus(application model.Application, history model.ApplicationStatusHistory) (model.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateApplicationStatus", application, history)
+	ret0, _ := ret[0].(model.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateApplicationStatus indicates an expected call of UpdateApplicationStatus.
+func (mr *MockApplicationRepositoryMockRecorder) UpdateApplicationStatus(application, history any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApplicationStatus", reflect.TypeOf((*MockApplicationRepository)(nil).UpdateApplicationStatus), application, history)
+}
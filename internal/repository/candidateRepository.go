@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"errors"
+	"job-portal-api/internal/model"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -source=candidateRepository.go -destination=candidateRepository_mock.go -package=repository
+type CandidateRepository interface {
+	GetProfile(uID uint) (model.CandidateProfile, error)
+	SaveProfile(profile model.CandidateProfile) (model.CandidateProfile, error)
+	AddProfileSkills(uID uint, skills model.DetectedSkills) error
+}
+
+func NewCandidateRepo(db *gorm.DB) (CandidateRepository, error) {
+	if db == nil {
+		log.Info().Msg("database cannot be nil")
+		return nil, errors.New("data base cannot be nil")
+	}
+
+	return &Repo{
+		db: db,
+	}, nil
+}
+
+func (r *Repo) GetProfile(uID uint) (model.CandidateProfile, error) {
+
+	var profile model.CandidateProfile
+
+	output := r.db.Preload("TechnologyStack").Preload("Qualifications").Preload("Location").Preload("Shift").Preload("Jobtype").Where("user_id = ?", uID).First(&profile)
+	if errors.Is(output.Error, gorm.ErrRecordNotFound) {
+		return model.CandidateProfile{}, ErrNotFound
+	}
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error in fetching candidate profile")
+		return model.CandidateProfile{}, errors.New("could not fetch the profile")
+	}
+
+	return profile, nil
+}
+
+// SaveProfile creates the profile of the user or overwrites it along with all of its associations
+func (r *Repo) SaveProfile(profile model.CandidateProfile) (model.CandidateProfile, error) {
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var existing model.CandidateProfile
+		err := tx.Select("id", "created_at").Where("user_id = ?", profile.UserID).First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		profile.ID = existing.ID
+		profile.CreatedAt = existing.CreatedAt
+
+		err = tx.Omit(clause.Associations).Save(&profile).Error
+		if err != nil {
+			return err
+		}
+
+		associations := []struct {
+			name   string
+			values interface{}
+		}{
+			{"TechnologyStack", profile.TechnologyStack},
+			{"Qualifications", profile.Qualifications},
+			{"Location", profile.Location},
+			{"Shift", profile.Shift},
+			{"Jobtype", profile.Jobtype},
+		}
+		for _, v := range associations {
+			err = tx.Model(&profile).Association(v.name).Replace(v.values)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error in saving candidate profile")
+		return model.CandidateProfile{}, errors.New("could not save the profile")
+	}
+
+	return r.GetProfile(profile.UserID)
+}
+
+// AddProfileSkills adds the technology stack and qualifications to the profile keeping the ones it already has
+func (r *Repo) AddProfileSkills(uID uint, skills model.DetectedSkills) error {
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var profile model.CandidateProfile
+		err := tx.Select("id").Where("user_id = ?", uID).First(&profile).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var stack []model.TechnologyStack
+		for _, v := range skills.TechnologyStack {
+			stack = append(stack, model.TechnologyStack{Model: gorm.Model{ID: v}})
+		}
+		if len(stack) > 0 {
+			err = tx.Model(&profile).Association("TechnologyStack").Append(stack)
+			if err != nil {
+				return err
+			}
+		}
+
+		var qualifications []model.Qualification
+		for _, v := range skills.Qualifications {
+			qualifications = append(qualifications, model.Qualification{Model: gorm.Model{ID: v}})
+		}
+		if len(qualifications) > 0 {
+			err = tx.Model(&profile).Association("Qualifications").Append(qualifications)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if errors.Is(err, ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error in adding skills to the profile")
+		return errors.New("could not add skills to the profile")
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: candidateRepository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=candidateRepository.go -destination=candidateRepository_mock.go -package=repository
+//
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	model "job-portal-api/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCandidateRepository is a mock of CandidateRepository interface.
+type MockCandidateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCandidateRepositoryMockRecorder
+}
+
+// MockCandidateRepositoryMockRecorder is the mock recorder for MockCandidateRepository.
+type MockCandidateRepositoryMockRecorder struct {
+	mock *MockCandidateRepository
+}
+
+// NewMockCandidateRepository creates a new mock instance.
+func NewMockCandidateRepository(ctrl *gomock.Controller) *MockCandidateRepository {
+	mock := &MockCandidateRepository{ctrl: ctrl}
+	mock.recorder = &MockCandidateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCandidateRepository) EXPECT() *MockCandidateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddProfileSkills mocks base method.
+func (m *MockCandidateRepository) AddProfileSkills(uID uint, skills model.DetectedSkills) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProfileSkills", uID, skills)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProfileSkills indicates an expected call of AddProfileSkills.
+func (mr *MockCandidateRepositoryMockRecorder) AddProfileSkills(uID, skills any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProfileSkills", reflect.TypeOf((*MockCandidateRepository)(nil).AddProfileSkills), uID, skills)
+}
+
+// GetProfile mocks base method.
+func (m *MockCandidateRepository) GetProfile(uID uint) (model.CandidateProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", uID)
+	ret0, _ := ret[0].(model.CandidateProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockCandidateRepositoryMockRecorder) GetProfile(uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockCandidateRepository)(nil).GetProfile), uID)
+}
+
+// SaveProfile mocks base method.
+func (m *MockCandidateRepository) SaveProfile(profile model.CandidateProfile) (model.CandidateProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProfile", profile)
+	ret0, _ := ret[0].(model.CandidateProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveProfile indicates an expected call of SaveProfile.
+func (mr *MockCandidateRepositoryMockRecorder) SaveProfile(profile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProfile", reflect.TypeOf((*MockCandidateRepository)(nil).SaveProfile), profile)
+}
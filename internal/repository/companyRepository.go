@@ -10,9 +10,11 @@ import (
 
 //go:generate mockgen -source=companyRepository.go -destination=companyRepository_mock.go -package=repository
 type ComapnyRepo interface {
-	CreateComapny(company model.Company) (model.Company, error)
+	CreateComapny(company model.Company, ownerID uint) (model.Company, error)
 	GetCompanyByID(cID uint64) (model.Company, error)
 	GetAllCompanies() ([]model.Company, error)
+	UpdateCompany(company model.Company) (model.Company, error)
+	DeleteCompany(cID uint) ([]uint, error)
 }
 
 func NewCompanyRepo(db *gorm.DB) (ComapnyRepo, error) {
@@ -25,12 +27,27 @@ func NewCompanyRepo(db *gorm.DB) (ComapnyRepo, error) {
 	}, nil
 }
 
-func (r *Repo) CreateComapny(company model.Company) (model.Company, error) {
+func (r *Repo) CreateComapny(company model.Company, ownerID uint) (model.Company, error) {
 
-	output := r.db.Create(&company)
+	//company and its owner are created together so a company never exists without an owner
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		output := tx.Create(&company)
+		if output.Error != nil {
+			return output.Error
+		}
 
-	if output.Error != nil {
-		log.Error().Err(output.Error).Msg("error in creating company table")
+		owner := model.CompanyMember{
+			CompanyID: company.ID,
+			UserID:    ownerID,
+			Role:      model.MemberRoleOwner,
+			Status:    model.MemberStatusActive,
+			InvitedBy: ownerID,
+		}
+		return tx.Create(&owner).Error
+	})
+
+	if err != nil {
+		log.Error().Err(err).Msg("error in creating company table")
 		return model.Company{}, errors.New("error in creating table")
 	}
 
@@ -41,6 +58,10 @@ func (r *Repo) GetCompanyByID(cID uint64) (model.Company, error) {
 
 	var companydata model.Company
 	output := r.db.Where("id = ?", cID).First(&companydata)
+	if errors.Is(output.Error, gorm.ErrRecordNotFound) {
+		log.Error().Err(output.Error).Msg("error company id does not exists")
+		return model.Company{}, ErrNotFound
+	}
 	if output.Error != nil {
 		log.Error().Err(output.Error).Msg("error company id does not exists")
 		return model.Company{}, errors.New("error company id does not exists")
@@ -60,3 +81,56 @@ func (r *Repo) GetAllCompanies() ([]model.Company, error) {
 
 	return companiesData, nil
 }
+
+func (r *Repo) UpdateCompany(company model.Company) (model.Company, error) {
+
+	//the company name is part of the search document of its jobs
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Save(&company).Error
+		if err != nil {
+			return err
+		}
+		return refreshJobSearch(tx, "jobs.cid = ?", company.ID)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error in updating company")
+		return model.Company{}, errors.New("could not update the company")
+	}
+
+	return company, nil
+}
+
+// DeleteCompany soft deletes the company and closes all of its open jobs in the same transaction,
+// the ids of the closed jobs are returned
+func (r *Repo) DeleteCompany(cID uint) ([]uint, error) {
+
+	var jobIDs []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		output := tx.Delete(&model.Company{}, cID)
+		if output.Error != nil {
+			return output.Error
+		}
+		if output.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		err := tx.Model(&model.Job{}).Where("cid = ? AND closed = ?", cID, false).Pluck("id", &jobIDs).Error
+		if err != nil {
+			return err
+		}
+		if len(jobIDs) == 0 {
+			return nil
+		}
+
+		return tx.Model(&model.Job{}).Where("id IN ?", jobIDs).Update("closed", true).Error
+	})
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error in deleting company")
+		return nil, errors.New("could not delete the company")
+	}
+
+	return jobIDs, nil
+}
@@ -39,18 +39,33 @@ func (m *MockComapnyRepo) EXPECT() *MockComapnyRepoMockRecorder {
 }
 
 // CreateComapny mocks base method.
-func (m *MockComapnyRepo) CreateComapny(company model.Company) (model.Company, error) {
+func (m *MockComapnyRepo) CreateComapny(company model.Company, ownerID uint) (model.Company, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateComapny", company)
+	ret := m.ctrl.Call(m, "CreateComapny", company, ownerID)
 	ret0, _ := ret[0].(model.Company)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateComapny indicates an expected call of CreateComapny.
-func (mr *MockComapnyRepoMockRecorder) CreateComapny(company any) *gomock.Call {
+func (mr *MockComapnyRepoMockRecorder) CreateComapny(company, ownerID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComapny", reflect.TypeOf((*MockComapnyRepo)(nil).CreateComapny), company)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComapny", reflect.TypeOf((*MockComapnyRepo)(nil).CreateComapny), company, ownerID)
+}
+
+// DeleteCompany mocks base method.
+func (m *MockComapnyRepo) DeleteCompany(cID uint) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCompany", cID)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCompany indicates an expected call of DeleteCompany.
+func (mr *MockComapnyRepoMockRecorder) DeleteCompany(cID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompany", reflect.TypeOf((*MockComapnyRepo)(nil).DeleteCompany), cID)
 }
 
 // GetAllCompanies mocks base method.
@@ -82,3 +97,18 @@ func (mr *MockComapnyRepoMockRecorder) GetCompanyByID(cID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyByID", reflect.TypeOf((*MockComapnyRepo)(nil).GetCompanyByID), cID)
 }
+
+// UpdateCompany mocks base method.
+func (m *MockComapnyRepo) UpdateCompany(company model.Company) (model.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCompany", company)
+	ret0, _ := ret[0].(model.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCompany indicates an expected call of UpdateCompany.
+func (mr *MockComapnyRepoMockRecorder) UpdateCompany(company any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCompany", reflect.TypeOf((*MockComapnyRepo)(nil).UpdateCompany), company)
+}
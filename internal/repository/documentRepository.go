@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"errors"
+	"job-portal-api/internal/model"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=documentRepository.go -destination=documentRepository_mock.go -package=repository
+type DocumentRepository interface {
+	CreateDocument(document model.Document) (model.Document, error)
+	GetDocumentByID(dID uint) (model.Document, error)
+	GetApplicationDocuments(aID uint, uID uint) ([]model.Document, error)
+	SetDocumentSkills(dID uint, skills model.DetectedSkills) error
+}
+
+func NewDocumentRepo(db *gorm.DB) (DocumentRepository, error) {
+	if db == nil {
+		log.Info().Msg("database cannot be nil")
+		return nil, errors.New("database cannot be nil")
+	}
+	return &Repo{
+		db: db,
+	}, nil
+}
+
+func (r *Repo) CreateDocument(document model.Document) (model.Document, error) {
+
+	output := r.db.Create(&document)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error in creating document")
+		return model.Document{}, errors.New("could not create document")
+	}
+
+	return document, nil
+}
+
+func (r *Repo) GetDocumentByID(dID uint) (model.Document, error) {
+
+	var document model.Document
+
+	output := r.db.Where("id = ?", dID).First(&document)
+	if errors.Is(output.Error, gorm.ErrRecordNotFound) {
+		return model.Document{}, ErrNotFound
+	}
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error in fetching document")
+		return model.Document{}, errors.New("could not fetch the document")
+	}
+
+	return document, nil
+}
+
+// GetApplicationDocuments returns the documents attached to the application along with the
+// profile documents of the candidate who applied
+func (r *Repo) GetApplicationDocuments(aID uint, uID uint) ([]model.Document, error) {
+
+	var documents []model.Document
+
+	output := r.db.Where("application_id = ? OR (user_id = ? AND application_id IS NULL)", aID, uID).Order("created_at desc").Find(&documents)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error while fetching documents")
+		return nil, errors.New("error while fetching documents")
+	}
+
+	return documents, nil
+}
+
+func (r *Repo) SetDocumentSkills(dID uint, skills model.DetectedSkills) error {
+
+	output := r.db.Model(&model.Document{}).Where("id = ?", dID).Update("skills", skills)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error in saving detected skills")
+		return errors.New("could not save the detected skills")
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: documentRepository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=documentRepository.go -destination=documentRepository_mock.go -package=repository
+//
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	model "job-portal-api/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDocumentRepository is a mock of DocumentRepository interface.
+type MockDocumentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentRepositoryMockRecorder
+}
+
+// MockDocumentRepositoryMockRecorder is the mock recorder for MockDocumentRepository.
+type MockDocumentRepositoryMockRecorder struct {
+	mock *MockDocumentRepository
+}
+
+// NewMockDocumentRepository creates a new mock instance.
+func NewMockDocumentRepository(ctrl *gomock.Controller) *MockDocumentRepository {
+	mock := &MockDocumentRepository{ctrl: ctrl}
+	mock.recorder = &MockDocumentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocumentRepository) EXPECT() *MockDocumentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateDocument mocks base method.
+func (m *MockDocumentRepository) CreateDocument(document model.Document) (model.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDocument", document)
+	ret0, _ := ret[0].(model.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDocument indicates an expected call of CreateDocument.
+func (mr *MockDocumentRepositoryMockRecorder) CreateDocument(document any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDocument", reflect.TypeOf((*MockDocumentRepository)(nil).CreateDocument), document)
+}
+
+// GetApplicationDocuments mocks base method.
+func (m *MockDocumentRepository) GetApplicationDocuments(aID, uID uint) ([]model.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicationDocuments", aID, uID)
+	ret0, _ := ret[0].([]model.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicationDocuments indicates an expected call of GetApplicationDocuments.
+func (mr *MockDocumentRepositoryMockRecorder) GetApplicationDocuments(aID, uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationDocuments", reflect.TypeOf((*MockDocumentRepository)(nil).GetApplicationDocuments), aID, uID)
+}
+
+// GetDocumentByID mocks base method.
+func (m *MockDocumentRepository) GetDocumentByID(dID uint) (model.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDocumentByID", dID)
+	ret0, _ := ret[0].(model.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDocumentByID indicates an expected call of GetDocumentByID.
+func (mr *MockDocumentRepositoryMockRecorder) GetDocumentByID(dID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDocumentByID", reflect.TypeOf((*MockDocumentRepository)(nil).GetDocumentByID), dID)
+}
+
+// SetDocumentSkills mocks base method.
+func (m *MockDocumentRepository) SetDocumentSkills(dID uint, skills model.DetectedSkills) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDocumentSkills", dID, skills)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDocumentSkills indicates an expected call of SetDocumentSkills.
+func (mr *MockDocumentRepositoryMockRecorder) SetDocumentSkills(dID, skills any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDocumentSkills", reflect.TypeOf((*MockDocumentRepository)(nil).SetDocumentSkills), dID, skills)
+}
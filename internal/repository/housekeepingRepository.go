@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"job-portal-api/internal/model"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=housekeepingRepository.go -destination=housekeepingRepository_mock.go -package=repository
+type HousekeepingRepository interface {
+	ExpireJobs(ctx context.Context, now time.Time) ([]uint, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (map[string]int64, []string, error)
+	RefreshJobStats(ctx context.Context, now time.Time) error
+}
+
+func NewHousekeepingRepo(db *gorm.DB) (HousekeepingRepository, error) {
+	if db == nil {
+		log.Info().Msg("database cannot be nil")
+		return nil, errors.New("data base cannot be nil")
+	}
+
+	return &Repo{
+		db: db,
+	}, nil
+}
+
+// ExpireJobs marks the published jobs past their expiry date as expired and returns their ids
+func (r *Repo) ExpireJobs(ctx context.Context, now time.Time) ([]uint, error) {
+
+	var ids []uint
+
+	output := r.db.WithContext(ctx).Raw("UPDATE jobs SET status = ?, updated_at = ? WHERE status = ? AND expires_at <= ? AND deleted_at IS NULL RETURNING id",
+		model.JobStatusExpired, now, model.JobStatusPublished, now).Scan(&ids)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error in expiring jobs")
+		return nil, errors.New("could not expire jobs")
+	}
+
+	return ids, nil
+}
+
+// jobJoinTables hold the many to many associations of a job
+var jobJoinTables = []string{"job_location", "job_techstack", "job_qualification", "job_shift", "job_type"}
+
+// PurgeDeleted removes for good the companies and jobs deleted before the given time along with
+// everything hanging off them, it returns the number of removed rows per table and the storage keys
+// of the removed documents
+func (r *Repo) PurgeDeleted(ctx context.Context, before time.Time) (map[string]int64, []string, error) {
+
+	purged := map[string]int64{}
+	var storageKeys []string
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var companyIDs []uint
+		err := tx.Unscoped().Model(&model.Company{}).Where("deleted_at < ?", before).Pluck("id", &companyIDs).Error
+		if err != nil {
+			return err
+		}
+
+		//jobs of a purged company go with it even when they were not deleted themselves
+		var jobIDs []uint
+		err = tx.Unscoped().Model(&model.Job{}).Where("deleted_at < ? OR cid IN ?", before, companyIDs).Pluck("id", &jobIDs).Error
+		if err != nil {
+			return err
+		}
+
+		var applicationIDs []uint
+		err = tx.Unscoped().Model(&model.Application{}).Where("deleted_at < ? OR jid IN ?", before, jobIDs).Pluck("id", &applicationIDs).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Where("application_id IN ?", applicationIDs).Delete(&model.ApplicationStatusHistory{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Model(&model.Document{}).Where("application_id IN ?", applicationIDs).Pluck("storage_key", &storageKeys).Error
+		if err != nil {
+			return err
+		}
+
+		output := tx.Unscoped().Where("application_id IN ?", applicationIDs).Delete(&model.Document{})
+		if output.Error != nil {
+			return output.Error
+		}
+		purged["documents"] = output.RowsAffected
+
+		output = tx.Unscoped().Where("id IN ?", applicationIDs).Delete(&model.Application{})
+		if output.Error != nil {
+			return output.Error
+		}
+		purged["applications"] = output.RowsAffected
+
+		for _, table := range jobJoinTables {
+			err = tx.Exec("DELETE FROM "+table+" WHERE job_id IN ?", jobIDs).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		err = tx.Where("job_id IN ?", jobIDs).Delete(&model.JobStats{}).Error
+		if err != nil {
+			return err
+		}
+
+		output = tx.Unscoped().Where("id IN ?", jobIDs).Delete(&model.Job{})
+		if output.Error != nil {
+			return output.Error
+		}
+		purged["jobs"] = output.RowsAffected
+
+		output = tx.Unscoped().Where("company_id IN ?", companyIDs).Delete(&model.CompanyMember{})
+		if output.Error != nil {
+			return output.Error
+		}
+		purged["company_members"] = output.RowsAffected
+
+		output = tx.Unscoped().Where("id IN ?", companyIDs).Delete(&model.Company{})
+		if output.Error != nil {
+			return output.Error
+		}
+		purged["companies"] = output.RowsAffected
+
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error in purging deleted rows")
+		return nil, nil, errors.New("could not purge deleted rows")
+	}
+
+	return purged, storageKeys, nil
+}
+
+// jobStatsQuery counts the applications of every job which is not deleted
+const jobStatsQuery = `INSERT INTO job_stats (job_id, applications, accepted, hired, refreshed_at)
+	SELECT jobs.id, count(applications.id), count(applications.id) FILTER (WHERE applications.accepted),
+		count(applications.id) FILTER (WHERE applications.status = ?), ?
+	FROM jobs LEFT JOIN applications ON applications.jid = jobs.id AND applications.deleted_at IS NULL
+	WHERE jobs.deleted_at IS NULL
+	GROUP BY jobs.id
+	ON CONFLICT (job_id) DO UPDATE SET applications = EXCLUDED.applications, accepted = EXCLUDED.accepted,
+		hired = EXCLUDED.hired, refreshed_at = EXCLUDED.refreshed_at`
+
+// RefreshJobStats recounts the stats of every job and drops the stats of deleted jobs
+func (r *Repo) RefreshJobStats(ctx context.Context, now time.Time) error {
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(jobStatsQuery, model.ApplicationHired, now).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec("DELETE FROM job_stats WHERE job_id NOT IN (SELECT id FROM jobs WHERE deleted_at IS NULL)").Error
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error in refreshing job stats")
+		return errors.New("could not refresh job stats")
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: housekeepingRepository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=housekeepingRepository.go -destination=housekeepingRepository_mock.go -package=repository
+//
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHousekeepingRepository is a mock of HousekeepingRepository interface.
+type MockHousekeepingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHousekeepingRepositoryMockRecorder
+}
+
+// MockHousekeepingRepositoryMockRecorder is the mock recorder for MockHousekeepingRepository.
+type MockHousekeepingRepositoryMockRecorder struct {
+	mock *MockHousekeepingRepository
+}
+
+// NewMockHousekeepingRepository creates a new mock instance.
+func NewMockHousekeepingRepository(ctrl *gomock.Controller) *MockHousekeepingRepository {
+	mock := &MockHousekeepingRepository{ctrl: ctrl}
+	mock.recorder = &MockHousekeepingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHousekeepingRepository) EXPECT() *MockHousekeepingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ExpireJobs mocks base method.
+func (m *MockHousekeepingRepository) ExpireJobs(ctx context.Context, now time.Time) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireJobs", ctx, now)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireJobs indicates an expected call of ExpireJobs.
+func (mr *MockHousekeepingRepositoryMockRecorder) ExpireJobs(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireJobs", reflect.TypeOf((*MockHousekeepingRepository)(nil).ExpireJobs), ctx, now)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockHousekeepingRepository) PurgeDeleted(ctx context.Context, before time.Time) (map[string]int64, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, before)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockHousekeepingRepositoryMockRecorder) PurgeDeleted(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockHousekeepingRepository)(nil).PurgeDeleted), ctx, before)
+}
+
+// RefreshJobStats mocks base method.
+func (m *MockHousekeepingRepository) RefreshJobStats(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshJobStats", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshJobStats indicates an expected call of RefreshJobStats.
+func (mr *MockHousekeepingRepositoryMockRecorder) RefreshJobStats(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshJobStats", reflect.TypeOf((*MockHousekeepingRepository)(nil).RefreshJobStats), ctx, now)
+}
@@ -3,17 +3,25 @@ package repository
 import (
 	"errors"
 	"job-portal-api/internal/model"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 //go:generate mockgen -source=jobRepository.go -destination=jobRepository_mock.go -package=repository
 type JobRepository interface {
 	CreateJob(jodData model.Job) (model.Response, error)
-	GetJobByCompanyID(cID uint) ([]model.Job, error)
+	GetJobByCompanyID(cID uint, listedOnly bool) ([]model.Job, error)
 	GetJobByJobID(cID uint) (model.Job, error)
-	GetAllJobs() ([]model.Job, error)
+	GetAllJobs(filter model.JobFilter) ([]model.Job, int64, error)
+	UpdateJob(jobData model.Job) (model.Job, error)
+	SetJobClosed(jID uint, closed bool) error
+	SetJobStatus(jID uint, status string) error
+	DeleteJob(jID uint) error
+	SearchJobs(search model.JobSearch) ([]model.JobSearchResult, int64, error)
+	RebuildJobSearch() error
 }
 
 func NewJobRepo(db *gorm.DB) (JobRepository, error) {
@@ -29,10 +37,16 @@ func NewJobRepo(db *gorm.DB) (JobRepository, error) {
 
 func (r *Repo) CreateJob(jobData model.Job) (model.Response, error) {
 
-	output := r.db.Create(&jobData)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&jobData).Error
+		if err != nil {
+			return err
+		}
+		return refreshJobSearch(tx, "jobs.id = ?", jobData.ID)
+	})
 
-	if output.Error != nil {
-		log.Error().Err(output.Error).Msg("error in creating job table")
+	if err != nil {
+		log.Error().Err(err).Msg("error in creating job table")
 		return model.Response{}, errors.New("could not create job")
 	}
 
@@ -41,11 +55,28 @@ func (r *Repo) CreateJob(jobData model.Job) (model.Response, error) {
 	}, nil
 }
 
-func (r *Repo) GetJobByCompanyID(cID uint) ([]model.Job, error) {
+// activeCompanies selects the ids of the companies which are not deleted, jobs of deleted companies
+// are kept for the applications pointing at them but are not listed
+func (r *Repo) activeCompanies() *gorm.DB {
+	return r.db.Model(&model.Company{}).Select("id")
+}
+
+// listedJobs keeps the published jobs which are open for applications and have not expired yet
+func listedJobs(query *gorm.DB) *gorm.DB {
+	return query.Where("status = ?", model.JobStatusPublished).Where("closed = ?", false).Where("expires_at IS NULL OR expires_at > ?", time.Now())
+}
+
+// GetJobByCompanyID returns the jobs of the company, only the listed ones when listedOnly is set
+func (r *Repo) GetJobByCompanyID(cID uint, listedOnly bool) ([]model.Job, error) {
 
 	var jobData []model.Job
 
-	output := r.db.Preload("Company").Preload("Location").Preload("TechnologyStack").Preload("Qualifications").Preload("Shift").Preload("Jobtype").Where("cid = ?", cID).Find(&jobData)
+	query := r.db.Preload("Company").Preload("Location").Preload("TechnologyStack").Preload("Qualifications").Preload("Shift").Preload("Jobtype").Where("cid = ?", cID).Where("cid IN (?)", r.activeCompanies())
+	if listedOnly {
+		query = listedJobs(query)
+	}
+
+	output := query.Find(&jobData)
 	if output.Error != nil || output.RowsAffected == 0 {
 		log.Error().Err(output.Error).Msg("error ivalid company id")
 		return nil, errors.New("invalid company id")
@@ -59,6 +90,10 @@ func (r *Repo) GetJobByJobID(jID uint) (model.Job, error) {
 	var jobData model.Job
 
 	output := r.db.Preload("Company").Preload("Location").Preload("TechnologyStack").Preload("Qualifications").Preload("Shift").Preload("Jobtype").Where("id = ?", jID).First(&jobData)
+	if errors.Is(output.Error, gorm.ErrRecordNotFound) {
+		log.Error().Err(output.Error).Msg("error in job id")
+		return model.Job{}, ErrNotFound
+	}
 	if output.Error != nil {
 		log.Error().Err(output.Error).Msg("error in job id")
 		return model.Job{}, errors.New("couls not find the job")
@@ -67,16 +102,261 @@ func (r *Repo) GetJobByJobID(jID uint) (model.Job, error) {
 	return jobData, nil
 }
 
-func (r *Repo) GetAllJobs() ([]model.Job, error) {
+// jobSortOrders maps the sort options of a listing to their order by clause
+var jobSortOrders = map[string]string{
+	model.JobSortNewest:       "created_at DESC, id DESC",
+	model.JobSortOldest:       "created_at ASC, id ASC",
+	model.JobSortExperience:   "min_experience ASC, id ASC",
+	model.JobSortNoticePeriod: "min_notice_period ASC, id ASC",
+	model.JobSortName:         "jobname ASC, id ASC",
+	model.JobSortSalary:       "max_salary = 0 DESC, max_salary DESC, id ASC", // open-ended salaries first
+}
+
+// filterJobs applies the conditions of the filter to a query on the jobs table
+func (r *Repo) filterJobs(query *gorm.DB, filter model.JobFilter) *gorm.DB {
+	query = listedJobs(query.Where("cid IN (?)", r.activeCompanies()))
 
-	var jobData []model.Job
+	if filter.CompanyID != 0 {
+		query = query.Where("cid = ?", filter.CompanyID)
+	}
+	if len(filter.Location) > 0 {
+		query = query.Where("id IN (?)", r.db.Table("job_location").Select("job_id").Where("location_id IN ?", filter.Location))
+	}
+	if len(filter.TechnologyStack) > 0 {
+		query = query.Where("id IN (?)", r.db.Table("job_techstack").Select("job_id").Where("technology_stack_id IN ?", filter.TechnologyStack))
+	}
+	if len(filter.Jobtype) > 0 {
+		query = query.Where("id IN (?)", r.db.Table("job_type").Select("job_id").Where("job_type_id IN ?", filter.Jobtype))
+	}
+	if len(filter.Shift) > 0 {
+		query = query.Where("id IN (?)", r.db.Table("job_shift").Select("job_id").Where("shift_id IN ?", filter.Shift))
+	}
+	if filter.MinExperience != nil {
+		query = query.Where("max_experience >= ?", *filter.MinExperience)
+	}
+	if filter.MaxExperience != nil {
+		query = query.Where("min_experience <= ?", *filter.MaxExperience)
+	}
+	if filter.MinNoticePeriod != nil {
+		query = query.Where("max_notice_period >= ?", *filter.MinNoticePeriod)
+	}
+	if filter.MaxNoticePeriod != nil {
+		query = query.Where("min_notice_period <= ?", *filter.MaxNoticePeriod)
+	}
+	if filter.PostedSince != nil {
+		query = query.Where("created_at >= ?", *filter.PostedSince)
+	}
+	if filter.MinSalary != nil {
+		query = query.Where("(max_salary >= ? OR max_salary = 0)", *filter.MinSalary)
+	}
+	if filter.Currency != "" {
+		query = query.Where("currency = ?", filter.Currency)
+	}
+	if filter.PayPeriod != "" {
+		query = query.Where("pay_period = ?", filter.PayPeriod)
+	}
+	if len(filter.WorkMode) > 0 {
+		query = query.Where("work_mode IN ?", filter.WorkMode)
+	}
+
+	return query
+}
 
-	output := r.db.Preload("Company").Preload("Location").Preload("TechnologyStack").Preload("Qualifications").Preload("Shift").Preload("Jobtype").Find(&jobData)
+// GetAllJobs returns one page of the jobs matching the filter and the total number of matching jobs,
+// page and page size are expected to be set by the caller
+func (r *Repo) GetAllJobs(filter model.JobFilter) ([]model.Job, int64, error) {
 
-	if output.Error != nil || output.RowsAffected == 0 {
+	var total int64
+
+	output := r.filterJobs(r.db.Model(&model.Job{}), filter).Count(&total)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error while counting jobs")
+		return nil, 0, errors.New("error while getting all jobs")
+	}
+
+	order, ok := jobSortOrders[filter.Sort]
+	if !ok {
+		order = jobSortOrders[model.JobSortNewest]
+	}
+
+	jobData := []model.Job{}
+
+	query := r.db.Preload("Company").Preload("Location").Preload("TechnologyStack").Preload("Qualifications").Preload("Shift").Preload("Jobtype")
+	output = r.filterJobs(query, filter).Order(order).Offset((filter.Page - 1) * filter.PageSize).Limit(filter.PageSize).Find(&jobData)
+	if output.Error != nil {
 		log.Error().Err(output.Error).Msg("error while retriving job data")
-		return nil, errors.New("error while getting all jobs")
+		return nil, 0, errors.New("error while getting all jobs")
 	}
 
-	return jobData, nil
+	return jobData, total, nil
+}
+
+// UpdateJob saves the job fields and replaces all of its many to many associations with the given ones
+func (r *Repo) UpdateJob(jobData model.Job) (model.Job, error) {
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit(clause.Associations).Save(&jobData).Error
+		if err != nil {
+			return err
+		}
+
+		associations := []struct {
+			name   string
+			values interface{}
+		}{
+			{"Location", jobData.Location},
+			{"TechnologyStack", jobData.TechnologyStack},
+			{"Qualifications", jobData.Qualifications},
+			{"Shift", jobData.Shift},
+			{"Jobtype", jobData.Jobtype},
+		}
+		for _, v := range associations {
+			err = tx.Model(&jobData).Association(v.name).Replace(v.values)
+			if err != nil {
+				return err
+			}
+		}
+
+		return refreshJobSearch(tx, "jobs.id = ?", jobData.ID)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error in updating job")
+		return model.Job{}, errors.New("could not update the job")
+	}
+
+	return r.GetJobByJobID(jobData.ID)
+}
+
+func (r *Repo) SetJobClosed(jID uint, closed bool) error {
+
+	output := r.db.Model(&model.Job{}).Where("id = ?", jID).Update("closed", closed)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error in closing job")
+		return errors.New("could not change the job state")
+	}
+	if output.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *Repo) SetJobStatus(jID uint, status string) error {
+
+	output := r.db.Model(&model.Job{}).Where("id = ?", jID).Update("status", status)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error in changing job status")
+		return errors.New("could not change the job status")
+	}
+	if output.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteJob soft deletes the job so its applications stay readable
+func (r *Repo) DeleteJob(jID uint) error {
+
+	output := r.db.Delete(&model.Job{}, jID)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error in deleting job")
+		return errors.New("could not delete the job")
+	}
+	if output.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// jobSearchDocument builds the search document of a job, its name weighs the most followed by
+// the company name and the skills and then the description
+const jobSearchDocument = `setweight(to_tsvector('english', coalesce(jobs.jobname, '')), 'A') ||
+	setweight(to_tsvector('english', coalesce((SELECT companies.company_name FROM companies WHERE companies.id = jobs.cid), '')), 'B') ||
+	setweight(to_tsvector('english', coalesce((SELECT string_agg(technology_stacks.stack_name, ' ') FROM job_techstack
+		JOIN technology_stacks ON technology_stacks.id = job_techstack.technology_stack_id WHERE job_techstack.job_id = jobs.id), '')), 'B') ||
+	setweight(to_tsvector('english', coalesce(jobs.description, '')), 'C')`
+
+// refreshJobSearch rebuilds the search document of the jobs matching the condition, it has to run
+// whenever a job, the name of its company or its skills change
+func refreshJobSearch(db *gorm.DB, condition string, args ...interface{}) error {
+	return db.Exec("UPDATE jobs SET search_vector = "+jobSearchDocument+" WHERE "+condition, args...).Error
+}
+
+// RebuildJobSearch fills the search document of the jobs created before search existed
+func (r *Repo) RebuildJobSearch() error {
+
+	err := refreshJobSearch(r.db, "jobs.search_vector IS NULL")
+	if err != nil {
+		log.Error().Err(err).Msg("error in rebuilding job search")
+		return errors.New("could not rebuild job search")
+	}
+
+	return nil
+}
+
+// SearchJobs returns one page of the jobs matching the keywords and the filter ordered by relevance
+// along with the total number of matching jobs
+func (r *Repo) SearchJobs(search model.JobSearch) ([]model.JobSearchResult, int64, error) {
+
+	//websearch syntax lets users write quoted phrases, or and -excluded words
+	const query = "websearch_to_tsquery('english', ?)"
+
+	var total int64
+
+	output := r.filterJobs(r.db.Model(&model.Job{}), search.JobFilter).Where("search_vector @@ "+query, search.Query).Count(&total)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error while counting searched jobs")
+		return nil, 0, errors.New("error while searching jobs")
+	}
+
+	var matches []struct {
+		ID      uint
+		Rank    float64
+		Snippet string
+	}
+
+	output = r.filterJobs(r.db.Model(&model.Job{}), search.JobFilter).
+		Select("id, ts_rank(search_vector, "+query+") AS rank, ts_headline('english', description, "+query+", 'MaxFragments=2, MaxWords=20, MinWords=5') AS snippet", search.Query, search.Query).
+		Where("search_vector @@ "+query, search.Query).
+		Order("rank DESC, id ASC").Offset((search.Page - 1) * search.PageSize).Limit(search.PageSize).
+		Scan(&matches)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error while searching jobs")
+		return nil, 0, errors.New("error while searching jobs")
+	}
+
+	results := []model.JobSearchResult{}
+	if len(matches) == 0 {
+		return results, total, nil
+	}
+
+	var ids []uint
+	for _, v := range matches {
+		ids = append(ids, v.ID)
+	}
+
+	var jobData []model.Job
+
+	output = r.db.Preload("Company").Preload("Location").Preload("TechnologyStack").Preload("Qualifications").Preload("Shift").Preload("Jobtype").Where("id IN ?", ids).Find(&jobData)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error while retriving searched jobs")
+		return nil, 0, errors.New("error while searching jobs")
+	}
+
+	jobs := make(map[uint]model.Job, len(jobData))
+	for _, v := range jobData {
+		jobs[v.ID] = v
+	}
+
+	for _, v := range matches {
+		results = append(results, model.JobSearchResult{
+			Job:     jobs[v.ID],
+			Rank:    v.Rank,
+			Snippet: v.Snippet,
+		})
+	}
+
+	return results, total, nil
 }
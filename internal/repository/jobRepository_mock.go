@@ -53,34 +53,49 @@ func (mr *MockJobRepositoryMockRecorder) CreateJob(jodData any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockJobRepository)(nil).CreateJob), jodData)
 }
 
+// DeleteJob mocks base method.
+func (m *MockJobRepository) DeleteJob(jID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteJob", jID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteJob indicates an expected call of DeleteJob.
+func (mr *MockJobRepositoryMockRecorder) DeleteJob(jID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJob", reflect.TypeOf((*MockJobRepository)(nil).DeleteJob), jID)
+}
+
 // GetAllJobs mocks base method.
-func (m *MockJobRepository) GetAllJobs() ([]model.Job, error) {
+func (m *MockJobRepository) GetAllJobs(filter model.JobFilter) ([]model.Job, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllJobs")
+	ret := m.ctrl.Call(m, "GetAllJobs", filter)
 	ret0, _ := ret[0].([]model.Job)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAllJobs indicates an expected call of GetAllJobs.
-func (mr *MockJobRepositoryMockRecorder) GetAllJobs() *gomock.Call {
+func (mr *MockJobRepositoryMockRecorder) GetAllJobs(filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllJobs", reflect.TypeOf((*MockJobRepository)(nil).GetAllJobs))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllJobs", reflect.TypeOf((*MockJobRepository)(nil).GetAllJobs), filter)
 }
 
 // GetJobByCompanyID mocks base method.
-func (m *MockJobRepository) GetJobByCompanyID(cID uint) ([]model.Job, error) {
+func (m *MockJobRepository) GetJobByCompanyID(cID uint, listedOnly bool) ([]model.Job, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetJobByCompanyID", cID)
+	ret := m.ctrl.Call(m, "GetJobByCompanyID", cID, listedOnly)
 	ret0, _ := ret[0].([]model.Job)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetJobByCompanyID indicates an expected call of GetJobByCompanyID.
-func (mr *MockJobRepositoryMockRecorder) GetJobByCompanyID(cID any) *gomock.Call {
+func (mr *MockJobRepositoryMockRecorder) GetJobByCompanyID(cID, listedOnly any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobByCompanyID", reflect.TypeOf((*MockJobRepository)(nil).GetJobByCompanyID), cID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobByCompanyID", reflect.TypeOf((*MockJobRepository)(nil).GetJobByCompanyID), cID, listedOnly)
 }
 
 // GetJobByJobID mocks base method.
@@ -97,3 +112,76 @@ func (mr *MockJobRepositoryMockRecorder) GetJobByJobID(cID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobByJobID", reflect.TypeOf((*MockJobRepository)(nil).GetJobByJobID), cID)
 }
+
+// RebuildJobSearch mocks base method.
+func (m *MockJobRepository) RebuildJobSearch() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildJobSearch")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebuildJobSearch indicates an expected call of RebuildJobSearch.
+func (mr *MockJobRepositoryMockRecorder) RebuildJobSearch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildJobSearch", reflect.TypeOf((*MockJobRepository)(nil).RebuildJobSearch))
+}
+
+// SearchJobs mocks base method.
+func (m *MockJobRepository) SearchJobs(search model.JobSearch) ([]model.JobSearchResult, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchJobs", search)
+	ret0, _ := ret[0].([]model.JobSearchResult)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchJobs indicates an expected call of SearchJobs.
+func (mr *MockJobRepositoryMockRecorder) SearchJobs(search any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchJobs", reflect.TypeOf((*MockJobRepository)(nil).SearchJobs), search)
+}
+
+// SetJobClosed mocks base method.
+func (m *MockJobRepository) SetJobClosed(jID uint, closed bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetJobClosed", jID, closed)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetJobClosed indicates an expected call of SetJobClosed.
+func (mr *MockJobRepositoryMockRecorder) SetJobClosed(jID, closed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetJobClosed", reflect.TypeOf((*MockJobRepository)(nil).SetJobClosed), jID, closed)
+}
+
+// SetJobStatus mocks base method.
+func (m *MockJobRepository) SetJobStatus(jID uint, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetJobStatus", jID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetJobStatus indicates an expected call of SetJobStatus.
+func (mr *MockJobRepositoryMockRecorder) SetJobStatus(jID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetJobStatus", reflect.TypeOf((*MockJobRepository)(nil).SetJobStatus), jID, status)
+}
+
+// UpdateJob mocks base method.
+func (m *MockJobRepository) UpdateJob(jobData model.Job) (model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJob", jobData)
+	ret0, _ := ret[0].(model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateJob indicates an expected call of UpdateJob.
+func (mr *MockJobRepositoryMockRecorder) UpdateJob(jobData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJob", reflect.TypeOf((*MockJobRepository)(nil).UpdateJob), jobData)
+}
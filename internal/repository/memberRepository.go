@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"errors"
+	"job-portal-api/internal/model"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=memberRepository.go -destination=memberRepository_mock.go -package=repository
+type MemberRepository interface {
+	AddMember(member model.CompanyMember) (model.CompanyMember, error)
+	GetMember(cID uint, uID uint) (model.CompanyMember, error)
+	GetMembersByCompanyID(cID uint) ([]model.CompanyMember, error)
+	UpdateMember(member model.CompanyMember) (model.CompanyMember, error)
+	RemoveMember(cID uint, uID uint) error
+}
+
+func NewMemberRepo(db *gorm.DB) (MemberRepository, error) {
+	if db == nil {
+		log.Info().Msg("database cannot be nil")
+		return nil, errors.New("database cannot be nil")
+	}
+	return &Repo{
+		db: db,
+	}, nil
+}
+
+func (r *Repo) AddMember(member model.CompanyMember) (model.CompanyMember, error) {
+
+	output := r.db.Create(&member)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error in adding company member")
+		return model.CompanyMember{}, errors.New("could not add company member")
+	}
+
+	return member, nil
+}
+
+func (r *Repo) GetMember(cID uint, uID uint) (model.CompanyMember, error) {
+
+	var member model.CompanyMember
+
+	output := r.db.Where("company_id = ? AND user_id = ?", cID, uID).First(&member)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error company member not found")
+		return model.CompanyMember{}, errors.New("company member not found")
+	}
+
+	return member, nil
+}
+
+func (r *Repo) GetMembersByCompanyID(cID uint) ([]model.CompanyMember, error) {
+
+	var members []model.CompanyMember
+
+	output := r.db.Where("company_id = ?", cID).Find(&members)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error while fetching company members")
+		return nil, errors.New("error while fetching company members")
+	}
+
+	return members, nil
+}
+
+func (r *Repo) UpdateMember(member model.CompanyMember) (model.CompanyMember, error) {
+
+	output := r.db.Save(&member)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error in updating company member")
+		return model.CompanyMember{}, errors.New("could not update company member")
+	}
+
+	return member, nil
+}
+
+func (r *Repo) RemoveMember(cID uint, uID uint) error {
+
+	//members are removed permanently so the user can be invited again later
+	output := r.db.Unscoped().Where("company_id = ? AND user_id = ?", cID, uID).Delete(&model.CompanyMember{})
+	if output.Error != nil || output.RowsAffected == 0 {
+		log.Error().Err(output.Error).Msg("error in removing company member")
+		return errors.New("could not remove company member")
+	}
+
+	return nil
+}
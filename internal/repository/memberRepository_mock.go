@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: memberRepository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=memberRepository.go -destination=memberRepository_mock.go -package=repository
+//
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	model "job-portal-api/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMemberRepository is a mock of MemberRepository interface.
+type MockMemberRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMemberRepositoryMockRecorder
+}
+
+// MockMemberRepositoryMockRecorder is the mock recorder for MockMemberRepository.
+type MockMemberRepositoryMockRecorder struct {
+	mock *MockMemberRepository
+}
+
+// NewMockMemberRepository creates a new mock instance.
+func NewMockMemberRepository(ctrl *gomock.Controller) *MockMemberRepository {
+	mock := &MockMemberRepository{ctrl: ctrl}
+	mock.recorder = &MockMemberRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMemberRepository) EXPECT() *MockMemberRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockMemberRepository) AddMember(member model.CompanyMember) (model.CompanyMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", member)
+	ret0, _ := ret[0].(model.CompanyMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockMemberRepositoryMockRecorder) AddMember(member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockMemberRepository)(nil).AddMember), member)
+}
+
+// GetMember mocks base method.
+func (m *MockMemberRepository) GetMember(cID, uID uint) (model.CompanyMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMember", cID, uID)
+	ret0, _ := ret[0].(model.CompanyMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMember indicates an expected call of GetMember.
+func (mr *MockMemberRepositoryMockRecorder) GetMember(cID, uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockMemberRepository)(nil).GetMember), cID, uID)
+}
+
+// GetMembersByCompanyID mocks base method.
+func (m *MockMemberRepository) GetMembersByCompanyID(cID uint) ([]model.CompanyMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembersByCompanyID", cID)
+	ret0, _ := ret[0].([]model.CompanyMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembersByCompanyID indicates an expected call of GetMembersByCompanyID.
+func (mr *MockMemberRepositoryMockRecorder) GetMembersByCompanyID(cID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersByCompanyID", reflect.TypeOf((*MockMemberRepository)(nil).GetMembersByCompanyID), cID)
+}
+
+// RemoveMember mocks base method.
+func (m *MockMemberRepository) RemoveMember(cID, uID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", cID, uID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockMemberRepositoryMockRecorder) RemoveMember(cID, uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockMemberRepository)(nil).RemoveMember), cID, uID)
+}
+
+// UpdateMember mocks base method.
+func (m *MockMemberRepository) UpdateMember(member model.CompanyMember) (model.CompanyMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMember", member)
+	ret0, _ := ret[0].(model.CompanyMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMember indicates an expected call of UpdateMember.
+func (mr *MockMemberRepositoryMockRecorder) UpdateMember(member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMember", reflect.TypeOf((*MockMemberRepository)(nil).UpdateMember), member)
+}
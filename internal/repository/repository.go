@@ -1,9 +1,18 @@
 package repository
 
 import (
+	"errors"
+
 	"gorm.io/gorm"
 )
 
+// ErrNotFound is returned when the requested row does not exist so callers can tell it apart
+// from a failing database
+var ErrNotFound = errors.New("record not found")
+
+// ErrDuplicate is returned when a row would break a unique constraint
+var ErrDuplicate = errors.New("record already exists")
+
 type Repo struct {
 	db *gorm.DB
 }
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"errors"
+	"job-portal-api/internal/model"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=taxonomyRepository.go -destination=taxonomyRepository_mock.go -package=repository
+type TaxonomyRepository interface {
+	GetTaxonomies(kind string, includeRetired bool) ([]model.Taxonomy, error)
+	CreateTaxonomy(kind string, name string) (model.Taxonomy, error)
+	RenameTaxonomy(kind string, id uint, name string) (model.Taxonomy, error)
+	SetTaxonomyRetired(kind string, id uint, retired bool) (model.Taxonomy, error)
+	GetUnusableTaxonomyIDs(kind string, ids []uint) ([]uint, error)
+}
+
+// ErrUnknownTaxonomy is returned for a kind which is not one of the model taxonomy kinds
+var ErrUnknownTaxonomy = errors.New("unknown taxonomy")
+
+// taxonomyTables holds the table and the name column of every taxonomy kind
+var taxonomyTables = map[string]struct {
+	table  string
+	column string
+}{
+	model.TaxonomyLocation:        {"locations", "place_name"},
+	model.TaxonomyTechnologyStack: {"technology_stacks", "stack_name"},
+	model.TaxonomyQualification:   {"qualifications", "qualification_required"},
+	model.TaxonomyShift:           {"shifts", "shift_type"},
+	model.TaxonomyJobType:         {"job_types", "job_type_name"},
+}
+
+func NewTaxonomyRepo(db *gorm.DB) (TaxonomyRepository, error) {
+	if db == nil {
+		log.Info().Msg("database cannot be nil")
+		return nil, errors.New("database cannot be nil")
+	}
+	return &Repo{
+		db: db,
+	}, nil
+}
+
+func (r *Repo) GetTaxonomies(kind string, includeRetired bool) ([]model.Taxonomy, error) {
+
+	t, ok := taxonomyTables[kind]
+	if !ok {
+		return nil, ErrUnknownTaxonomy
+	}
+
+	query := "SELECT id, " + t.column + " AS name, retired FROM " + t.table + " WHERE deleted_at IS NULL"
+	if !includeRetired {
+		query += " AND retired = false"
+	}
+
+	taxonomies := []model.Taxonomy{}
+
+	output := r.db.Raw(query + " ORDER BY " + t.column).Scan(&taxonomies)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Str("kind", kind).Msg("error while fetching taxonomies")
+		return nil, errors.New("error while fetching taxonomies")
+	}
+
+	return taxonomies, nil
+}
+
+func (r *Repo) CreateTaxonomy(kind string, name string) (model.Taxonomy, error) {
+
+	t, ok := taxonomyTables[kind]
+	if !ok {
+		return model.Taxonomy{}, ErrUnknownTaxonomy
+	}
+
+	var taxonomy model.Taxonomy
+
+	now := time.Now()
+	output := r.db.Raw("INSERT INTO "+t.table+" (created_at, updated_at, "+t.column+", retired) VALUES (?, ?, ?, false) RETURNING id, "+t.column+" AS name, retired",
+		now, now, name).Scan(&taxonomy)
+	if errors.Is(output.Error, gorm.ErrDuplicatedKey) {
+		return model.Taxonomy{}, ErrDuplicate
+	}
+	if output.Error != nil {
+		log.Error().Err(output.Error).Str("kind", kind).Msg("error in creating taxonomy")
+		return model.Taxonomy{}, errors.New("could not create taxonomy")
+	}
+
+	return taxonomy, nil
+}
+
+// RenameTaxonomy changes the name of the value, renaming a skill rebuilds the search document of the jobs asking for it
+func (r *Repo) RenameTaxonomy(kind string, id uint, name string) (model.Taxonomy, error) {
+
+	t, ok := taxonomyTables[kind]
+	if !ok {
+		return model.Taxonomy{}, ErrUnknownTaxonomy
+	}
+
+	var taxonomy model.Taxonomy
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		output := tx.Raw("UPDATE "+t.table+" SET "+t.column+" = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL RETURNING id, "+t.column+" AS name, retired",
+			name, time.Now(), id).Scan(&taxonomy)
+		if output.Error != nil {
+			return output.Error
+		}
+		if output.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		if kind == model.TaxonomyTechnologyStack {
+			return refreshJobSearch(tx, "jobs.id IN (SELECT job_id FROM job_techstack WHERE technology_stack_id = ?)", id)
+		}
+		return nil
+	})
+	if errors.Is(err, ErrNotFound) {
+		return model.Taxonomy{}, ErrNotFound
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return model.Taxonomy{}, ErrDuplicate
+	}
+	if err != nil {
+		log.Error().Err(err).Str("kind", kind).Msg("error in renaming taxonomy")
+		return model.Taxonomy{}, errors.New("could not rename taxonomy")
+	}
+
+	return taxonomy, nil
+}
+
+func (r *Repo) SetTaxonomyRetired(kind string, id uint, retired bool) (model.Taxonomy, error) {
+
+	t, ok := taxonomyTables[kind]
+	if !ok {
+		return model.Taxonomy{}, ErrUnknownTaxonomy
+	}
+
+	var taxonomy model.Taxonomy
+
+	output := r.db.Raw("UPDATE "+t.table+" SET retired = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL RETURNING id, "+t.column+" AS name, retired",
+		retired, time.Now(), id).Scan(&taxonomy)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Str("kind", kind).Msg("error in retiring taxonomy")
+		return model.Taxonomy{}, errors.New("could not change taxonomy")
+	}
+	if output.RowsAffected == 0 {
+		return model.Taxonomy{}, ErrNotFound
+	}
+
+	return taxonomy, nil
+}
+
+// GetUnusableTaxonomyIDs returns the given ids which do not exist or are retired
+func (r *Repo) GetUnusableTaxonomyIDs(kind string, ids []uint) ([]uint, error) {
+
+	t, ok := taxonomyTables[kind]
+	if !ok {
+		return nil, ErrUnknownTaxonomy
+	}
+
+	var usable []uint
+
+	output := r.db.Raw("SELECT id FROM "+t.table+" WHERE id IN ? AND deleted_at IS NULL AND retired = false", ids).Scan(&usable)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Str("kind", kind).Msg("error while checking taxonomy ids")
+		return nil, errors.New("error while checking taxonomy ids")
+	}
+
+	found := make(map[uint]bool, len(usable))
+	for _, v := range usable {
+		found[v] = true
+	}
+
+	var unusable []uint
+	for _, v := range ids {
+		if !found[v] {
+			unusable = append(unusable, v)
+			//a repeated id is reported once
+			found[v] = true
+		}
+	}
+
+	return unusable, nil
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: taxonomyRepository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=taxonomyRepository.go -destination=taxonomyRepository_mock.go -package=repository
+//
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	model "job-portal-api/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTaxonomyRepository is a mock of TaxonomyRepository interface.
+type MockTaxonomyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxonomyRepositoryMockRecorder
+}
+
+// MockTaxonomyRepositoryMockRecorder is the mock recorder for MockTaxonomyRepository.
+type MockTaxonomyRepositoryMockRecorder struct {
+	mock *MockTaxonomyRepository
+}
+
+// NewMockTaxonomyRepository creates a new mock instance.
+func NewMockTaxonomyRepository(ctrl *gomock.Controller) *MockTaxonomyRepository {
+	mock := &MockTaxonomyRepository{ctrl: ctrl}
+	mock.recorder = &MockTaxonomyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxonomyRepository) EXPECT() *MockTaxonomyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTaxonomy mocks base method.
+func (m *MockTaxonomyRepository) CreateTaxonomy(kind, name string) (model.Taxonomy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaxonomy", kind, name)
+	ret0, _ := ret[0].(model.Taxonomy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaxonomy indicates an expected call of CreateTaxonomy.
+func (mr *MockTaxonomyRepositoryMockRecorder) CreateTaxonomy(kind, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaxonomy", reflect.TypeOf((*MockTaxonomyRepository)(nil).CreateTaxonomy), kind, name)
+}
+
+// GetTaxonomies mocks base method.
+func (m *MockTaxonomyRepository) GetTaxonomies(kind string, includeRetired bool) ([]model.Taxonomy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxonomies", kind, includeRetired)
+	ret0, _ := ret[0].([]model.Taxonomy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxonomies indicates an expected call of GetTaxonomies.
+func (mr *MockTaxonomyRepositoryMockRecorder) GetTaxonomies(kind, includeRetired any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxonomies", reflect.TypeOf((*MockTaxonomyRepository)(nil).GetTaxonomies), kind, includeRetired)
+}
+
+// GetUnusableTaxonomyIDs mocks base method.
+func (m *MockTaxonomyRepository) GetUnusableTaxonomyIDs(kind string, ids []uint) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnusableTaxonomyIDs", kind, ids)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnusableTaxonomyIDs indicates an expected call of GetUnusableTaxonomyIDs.
+func (mr *MockTaxonomyRepositoryMockRecorder) GetUnusableTaxonomyIDs(kind, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnusableTaxonomyIDs", reflect.TypeOf((*MockTaxonomyRepository)(nil).GetUnusableTaxonomyIDs), kind, ids)
+}
+
+// RenameTaxonomy mocks base method.
+func (m *MockTaxonomyRepository) RenameTaxonomy(kind string, id uint, name string) (model.Taxonomy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTaxonomy", kind, id, name)
+	ret0, _ := ret[0].(model.Taxonomy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameTaxonomy indicates an expected call of RenameTaxonomy.
+func (mr *MockTaxonomyRepositoryMockRecorder) RenameTaxonomy(kind, id, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTaxonomy", reflect.TypeOf((*MockTaxonomyRepository)(nil).RenameTaxonomy), kind, id, name)
+}
+
+// SetTaxonomyRetired mocks base method.
+func (m *MockTaxonomyRepository) SetTaxonomyRetired(kind string, id uint, retired bool) (model.Taxonomy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaxonomyRetired", kind, id, retired)
+	ret0, _ := ret[0].(model.Taxonomy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTaxonomyRetired indicates an expected call of SetTaxonomyRetired.
+func (mr *MockTaxonomyRepositoryMockRecorder) SetTaxonomyRetired(kind, id, retired any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaxonomyRetired", reflect.TypeOf((*MockTaxonomyRepository)(nil).SetTaxonomyRetired), kind, id, retired)
+}
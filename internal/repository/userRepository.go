@@ -3,6 +3,7 @@ package repository
 import (
 	"errors"
 	"job-portal-api/internal/model"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
@@ -12,6 +13,16 @@ import (
 type UserRepository interface {
 	CreateUser(userData model.User) (model.User, error)
 	CheckUser(email string) (model.User, error)
+	GetUserByID(uID uint) (model.User, error)
+	UpdateUserRole(uID uint, role string) error
+	CreatePasswordReset(reset model.PasswordReset) error
+	GetPasswordReset(tokenHash string) (model.PasswordReset, error)
+	GetLatestPasswordReset(uID uint) (model.PasswordReset, error)
+	ResetPassword(resetID uint, uID uint, hashedPassword string) error
+	CreateEmailVerification(verification model.EmailVerification) error
+	GetEmailVerification(tokenHash string) (model.EmailVerification, error)
+	GetLatestEmailVerification(uID uint) (model.EmailVerification, error)
+	VerifyEmail(verificationID uint, uID uint) error
 }
 
 func NewUserRepo(db *gorm.DB) (UserRepository, error) {
@@ -39,6 +50,9 @@ func (r *Repo) CheckUser(email string) (model.User, error) {
 
 	data := r.db.Where("email_id = ?", email).First(&userData)
 
+	if errors.Is(data.Error, gorm.ErrRecordNotFound) {
+		return model.User{}, ErrNotFound
+	}
 	if data.Error != nil {
 		log.Error().Err(data.Error).Msg("error email not found in database")
 		return model.User{}, errors.New("error email not found")
@@ -46,3 +60,196 @@ func (r *Repo) CheckUser(email string) (model.User, error) {
 
 	return userData, nil
 }
+
+func (r *Repo) GetUserByID(uID uint) (model.User, error) {
+
+	var userData model.User
+
+	output := r.db.Where("id = ?", uID).First(&userData)
+	if errors.Is(output.Error, gorm.ErrRecordNotFound) {
+		return model.User{}, ErrNotFound
+	}
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error while fetching user")
+		return model.User{}, errors.New("error while fetching user")
+	}
+
+	return userData, nil
+}
+
+func (r *Repo) UpdateUserRole(uID uint, role string) error {
+
+	output := r.db.Model(&model.User{}).Where("id = ?", uID).Update("role", role)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error in updating user role")
+		return errors.New("could not update user role")
+	}
+	if output.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *Repo) CreatePasswordReset(reset model.PasswordReset) error {
+
+	output := r.db.Create(&reset)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error in creating password reset")
+		return errors.New("could not create password reset")
+	}
+
+	return nil
+}
+
+func (r *Repo) GetPasswordReset(tokenHash string) (model.PasswordReset, error) {
+
+	var reset model.PasswordReset
+
+	output := r.db.Where("token_hash = ?", tokenHash).First(&reset)
+	if errors.Is(output.Error, gorm.ErrRecordNotFound) {
+		return model.PasswordReset{}, ErrNotFound
+	}
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error while fetching password reset")
+		return model.PasswordReset{}, errors.New("error while fetching password reset")
+	}
+
+	return reset, nil
+}
+
+// GetLatestPasswordReset returns the password reset last sent to the user
+func (r *Repo) GetLatestPasswordReset(uID uint) (model.PasswordReset, error) {
+
+	var reset model.PasswordReset
+
+	output := r.db.Where("user_id = ?", uID).Order("created_at desc").First(&reset)
+	if errors.Is(output.Error, gorm.ErrRecordNotFound) {
+		return model.PasswordReset{}, ErrNotFound
+	}
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error while fetching password reset")
+		return model.PasswordReset{}, errors.New("error while fetching password reset")
+	}
+
+	return reset, nil
+}
+
+// ResetPassword uses up the reset, sets the password and ends the other resets, a used reset returns ErrNotFound
+func (r *Repo) ResetPassword(resetID uint, uID uint, hashedPassword string) error {
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		output := tx.Model(&model.PasswordReset{}).Where("id = ? AND used_at IS NULL", resetID).Update("used_at", now)
+		if output.Error != nil {
+			return output.Error
+		}
+		if output.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		output = tx.Model(&model.User{}).Where("id = ?", uID).Update("password", hashedPassword)
+		if output.Error != nil {
+			return output.Error
+		}
+		if output.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		return tx.Model(&model.PasswordReset{}).Where("user_id = ? AND used_at IS NULL", uID).Update("used_at", now).Error
+	})
+
+	if errors.Is(err, ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error in resetting password")
+		return errors.New("could not reset password")
+	}
+
+	return nil
+}
+
+func (r *Repo) CreateEmailVerification(verification model.EmailVerification) error {
+
+	output := r.db.Create(&verification)
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error in creating email verification")
+		return errors.New("could not create email verification")
+	}
+
+	return nil
+}
+
+func (r *Repo) GetEmailVerification(tokenHash string) (model.EmailVerification, error) {
+
+	var verification model.EmailVerification
+
+	output := r.db.Where("token_hash = ?", tokenHash).First(&verification)
+	if errors.Is(output.Error, gorm.ErrRecordNotFound) {
+		return model.EmailVerification{}, ErrNotFound
+	}
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error while fetching email verification")
+		return model.EmailVerification{}, errors.New("error while fetching email verification")
+	}
+
+	return verification, nil
+}
+
+// GetLatestEmailVerification returns the verification last sent to the user
+func (r *Repo) GetLatestEmailVerification(uID uint) (model.EmailVerification, error) {
+
+	var verification model.EmailVerification
+
+	output := r.db.Where("user_id = ?", uID).Order("created_at desc").First(&verification)
+	if errors.Is(output.Error, gorm.ErrRecordNotFound) {
+		return model.EmailVerification{}, ErrNotFound
+	}
+	if output.Error != nil {
+		log.Error().Err(output.Error).Msg("error while fetching email verification")
+		return model.EmailVerification{}, errors.New("error while fetching email verification")
+	}
+
+	return verification, nil
+}
+
+// VerifyEmail uses up the verification, marks the email verified and ends the other verifications
+func (r *Repo) VerifyEmail(verificationID uint, uID uint) error {
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		output := tx.Model(&model.EmailVerification{}).Where("id = ? AND used_at IS NULL", verificationID).Update("used_at", now)
+		if output.Error != nil {
+			return output.Error
+		}
+		if output.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		output = tx.Model(&model.User{}).Where("id = ?", uID).Updates(map[string]interface{}{
+			"email_status":      model.EmailStatusVerified,
+			"email_verified_at": now,
+		})
+		if output.Error != nil {
+			return output.Error
+		}
+		if output.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		return tx.Model(&model.EmailVerification{}).Where("user_id = ? AND used_at IS NULL", uID).Update("used_at", now).Error
+	})
+
+	if errors.Is(err, ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error in verifying email")
+		return errors.New("could not verify email")
+	}
+
+	return nil
+}
@@ -53,6 +53,34 @@ func (mr *MockUserRepositoryMockRecorder) CheckUser(email any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUser", reflect.TypeOf((*MockUserRepository)(nil).CheckUser), email)
 }
 
+// CreateEmailVerification mocks base method.
+func (m *MockUserRepository) CreateEmailVerification(verification model.EmailVerification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailVerification", verification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEmailVerification indicates an expected call of CreateEmailVerification.
+func (mr *MockUserRepositoryMockRecorder) CreateEmailVerification(verification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailVerification", reflect.TypeOf((*MockUserRepository)(nil).CreateEmailVerification), verification)
+}
+
+// CreatePasswordReset mocks base method.
+func (m *MockUserRepository) CreatePasswordReset(reset model.PasswordReset) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordReset", reset)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordReset indicates an expected call of CreatePasswordReset.
+func (mr *MockUserRepositoryMockRecorder) CreatePasswordReset(reset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordReset", reflect.TypeOf((*MockUserRepository)(nil).CreatePasswordReset), reset)
+}
+
 // CreateUser mocks base method.
 func (m *MockUserRepository) CreateUser(userData model.User) (model.User, error) {
 	m.ctrl.T.Helper()
@@ -67,3 +95,120 @@ func (mr *MockUserRepositoryMockRecorder) CreateUser(userData any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserRepository)(nil).CreateUser), userData)
 }
+
+// GetEmailVerification mocks base method.
+func (m *MockUserRepository) GetEmailVerification(tokenHash string) (model.EmailVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailVerification", tokenHash)
+	ret0, _ := ret[0].(model.EmailVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailVerification indicates an expected call of GetEmailVerification.
+func (mr *MockUserRepositoryMockRecorder) GetEmailVerification(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailVerification", reflect.TypeOf((*MockUserRepository)(nil).GetEmailVerification), tokenHash)
+}
+
+// GetLatestEmailVerification mocks base method.
+func (m *MockUserRepository) GetLatestEmailVerification(uID uint) (model.EmailVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestEmailVerification", uID)
+	ret0, _ := ret[0].(model.EmailVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestEmailVerification indicates an expected call of GetLatestEmailVerification.
+func (mr *MockUserRepositoryMockRecorder) GetLatestEmailVerification(uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestEmailVerification", reflect.TypeOf((*MockUserRepository)(nil).GetLatestEmailVerification), uID)
+}
+
+// GetLatestPasswordReset mocks base method.
+func (m *MockUserRepository) GetLatestPasswordReset(uID uint) (model.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestPasswordReset", uID)
+	ret0, _ := ret[0].(model.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestPasswordReset indicates an expected call of GetLatestPasswordReset.
+func (mr *MockUserRepositoryMockRecorder) GetLatestPasswordReset(uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestPasswordReset", reflect.TypeOf((*MockUserRepository)(nil).GetLatestPasswordReset), uID)
+}
+
+// GetPasswordReset mocks base method.
+func (m *MockUserRepository) GetPasswordReset(tokenHash string) (model.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordReset", tokenHash)
+	ret0, _ := ret[0].(model.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordReset indicates an expected call of GetPasswordReset.
+func (mr *MockUserRepositoryMockRecorder) GetPasswordReset(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordReset", reflect.TypeOf((*MockUserRepository)(nil).GetPasswordReset), tokenHash)
+}
+
+// GetUserByID mocks base method.
+func (m *MockUserRepository) GetUserByID(uID uint) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", uID)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockUserRepositoryMockRecorder) GetUserByID(uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserRepository)(nil).GetUserByID), uID)
+}
+
+// ResetPassword mocks base method.
+func (m *MockUserRepository) ResetPassword(resetID, uID uint, hashedPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", resetID, uID, hashedPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockUserRepositoryMockRecorder) ResetPassword(resetID, uID, hashedPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUserRepository)(nil).ResetPassword), resetID, uID, hashedPassword)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockUserRepository) UpdateUserRole(uID uint, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", uID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockUserRepositoryMockRecorder) UpdateUserRole(uID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserRole), uID, role)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockUserRepository) VerifyEmail(verificationID, uID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", verificationID, uID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockUserRepositoryMockRecorder) VerifyEmail(verificationID, uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUserRepository)(nil).VerifyEmail), verificationID, uID)
+}
@@ -0,0 +1,121 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"job-portal-api/internal/cache"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Task is background work run every interval by a single replica
+type Task struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs its tasks in the background until it is stopped, before every run of a task it
+// takes a lock so only the replica holding it runs the task
+type Scheduler struct {
+	locker cache.Locker
+	tasks  []Task
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(locker cache.Locker, tasks ...Task) (*Scheduler, error) {
+	if locker == nil {
+		log.Info().Msg("locker cannot be nil")
+		return nil, errors.New("locker cannot be nil")
+	}
+	for _, v := range tasks {
+		if v.Interval <= 0 || v.Run == nil {
+			return nil, errors.New("task " + v.Name + " needs an interval and a function to run")
+		}
+	}
+	return &Scheduler{
+		locker: locker,
+		tasks:  tasks,
+	}, nil
+}
+
+// Start runs every task right away and then on its interval
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, v := range s.tasks {
+		s.wg.Add(1)
+		go func(task Task) {
+			defer s.wg.Done()
+
+			ticker := time.NewTicker(task.Interval)
+			defer ticker.Stop()
+
+			for {
+				s.runTask(ctx, task)
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}(v)
+	}
+}
+
+// lockTTL is how long the lock of a run is held, it runs out a little before the next tick so the
+// replica which ran the task can take it again while the other replicas ticking in between cannot
+func lockTTL(interval time.Duration) time.Duration {
+	return interval - interval/10
+}
+
+// runTask runs the task once when this replica gets its lock
+func (s *Scheduler) runTask(ctx context.Context, task Task) {
+	ttl := lockTTL(task.Interval)
+
+	leader, err := s.locker.TryLock(ctx, "scheduler:"+task.Name, ttl)
+	if err != nil {
+		log.Error().Err(err).Str("task", task.Name).Msg("error in taking task lock")
+		return
+	}
+	if !leader {
+		return
+	}
+
+	//a run may not last longer than the lock it holds
+	ctx, cancel := context.WithTimeout(ctx, ttl)
+	defer cancel()
+
+	start := time.Now()
+	err = task.Run(ctx)
+	if err != nil {
+		log.Error().Err(err).Str("task", task.Name).Msg("error in running task")
+		return
+	}
+	log.Info().Str("task", task.Name).Dur("took", time.Since(start)).Msg("task done")
+}
+
+// Stop stops scheduling tasks and waits for the running ones until the context is done
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,139 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"job-portal-api/internal/cache"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestScheduler_Start(t *testing.T) {
+	tests := []struct {
+		name     string
+		mockLock func() (bool, error)
+		wantRun  bool
+	}{
+		{
+			name: "leader runs the task",
+			mockLock: func() (bool, error) {
+				return true, nil
+			},
+			wantRun: true,
+		},
+		{
+			name: "other replica holds the lock",
+			mockLock: func() (bool, error) {
+				return false, nil
+			},
+			wantRun: false,
+		},
+		{
+			name: "error in taking the lock",
+			mockLock: func() (bool, error) {
+				return false, errors.New("error")
+			},
+			wantRun: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			ml := cache.NewMockLocker(mc)
+			ml.EXPECT().TryLock(gomock.Any(), "scheduler:test", 54*time.Minute).Return(tt.mockLock()).MinTimes(1)
+
+			var runs int32
+			s, err := NewScheduler(ml, Task{
+				Name:     "test",
+				Interval: time.Hour,
+				Run: func(ctx context.Context) error {
+					atomic.AddInt32(&runs, 1)
+					return nil
+				},
+			})
+			if err != nil {
+				t.Fatalf("NewScheduler() error = %v", err)
+			}
+
+			s.Start()
+			//every task runs once right away
+			deadline := time.Now().Add(time.Second)
+			for time.Now().Before(deadline) && tt.wantRun && atomic.LoadInt32(&runs) == 0 {
+				time.Sleep(time.Millisecond)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			err = s.Stop(ctx)
+			if err != nil {
+				t.Errorf("Scheduler.Stop() error = %v", err)
+			}
+			if (atomic.LoadInt32(&runs) == 1) != tt.wantRun {
+				t.Errorf("Scheduler ran the task %d times, want run %v", runs, tt.wantRun)
+			}
+		})
+	}
+}
+
+func TestNewScheduler(t *testing.T) {
+	mc := gomock.NewController(t)
+	_, err := NewScheduler(cache.NewMockLocker(mc), Task{Name: "test"})
+	if err == nil {
+		t.Errorf("NewScheduler() accepted a task without interval")
+	}
+	_, err = NewScheduler(nil)
+	if err == nil {
+		t.Errorf("NewScheduler() accepted a nil locker")
+	}
+}
+
+// expiringLocker hands out locks which run out like the redis ones do, a little late as the
+// expiry of a key is not exact
+type expiringLocker struct {
+	mu    sync.Mutex
+	locks map[string]time.Time
+}
+
+func (l *expiringLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if time.Now().Before(l.locks[key]) {
+		return false, nil
+	}
+	l.locks[key] = time.Now().Add(ttl + 2*time.Millisecond)
+	return true, nil
+}
+
+func TestScheduler_ConsecutiveTicks(t *testing.T) {
+	var runs int32
+	s, err := NewScheduler(&expiringLocker{locks: map[string]time.Time{}}, Task{
+		Name:     "test",
+		Interval: 50 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewScheduler() error = %v", err)
+	}
+
+	//the run right away and the two ticks after it, the lock of a run must not block the next tick
+	s.Start()
+	time.Sleep(125 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err = s.Stop(ctx)
+	if err != nil {
+		t.Errorf("Scheduler.Stop() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&runs); got != 3 {
+		t.Errorf("Scheduler ran the task %d times, want 3", got)
+	}
+}
@@ -0,0 +1,44 @@
+package scheduler
+
+import (
+	"context"
+	"job-portal-api/config"
+	"job-portal-api/internal/service"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HousekeepingTasks expires jobs, purges deleted rows and refreshes the job stats
+func HousekeepingTasks(housekeeping service.HousekeepingService, cfg config.SchedulerConfig) []Task {
+	return []Task{
+		{
+			Name:     "expire_jobs",
+			Interval: cfg.ExpireJobsInterval,
+			Run: func(ctx context.Context) error {
+				expired, err := housekeeping.ExpireJobs(ctx)
+				if err != nil {
+					return err
+				}
+				log.Info().Int("jobs", expired).Msg("expired jobs")
+				return nil
+			},
+		},
+		{
+			Name:     "purge_deleted",
+			Interval: cfg.PurgeInterval,
+			Run: func(ctx context.Context) error {
+				purged, err := housekeeping.PurgeDeleted(ctx, cfg.PurgeRetention)
+				if err != nil {
+					return err
+				}
+				log.Info().Interface("rows", purged).Msg("purged deleted rows")
+				return nil
+			},
+		},
+		{
+			Name:     "refresh_job_stats",
+			Interval: cfg.JobStatsInterval,
+			Run:      housekeeping.RefreshJobStats,
+		},
+	}
+}
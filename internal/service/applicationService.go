@@ -0,0 +1,162 @@
+package service
+
+import (
+	"errors"
+	"job-portal-api/internal/model"
+	"job-portal-api/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// applicationTransitions lists the states an application can move to from its current state,
+// hired, rejected and withdrawn are final
+var applicationTransitions = map[string][]string{
+	model.ApplicationApplied:      {model.ApplicationScreened, model.ApplicationRejected, model.ApplicationWithdrawn},
+	model.ApplicationScreened:     {model.ApplicationInterviewing, model.ApplicationRejected, model.ApplicationWithdrawn},
+	model.ApplicationInterviewing: {model.ApplicationOffered, model.ApplicationRejected, model.ApplicationWithdrawn},
+	model.ApplicationOffered:      {model.ApplicationHired, model.ApplicationRejected, model.ApplicationWithdrawn},
+}
+
+//go:generate mockgen -source=applicationService.go -destination=applicationService_mock.go -package=service
+type ApplicationService interface {
+	ViewApplicationsByJobID(jID uint, uID uint) ([]model.Application, error)
+	ViewApplicationByID(aID uint, uID uint) (model.Application, error)
+	MoveApplication(aID uint, uID uint, update model.ApplicationStatusUpdate) (model.Application, error)
+	ViewApplicationHistory(aID uint, uID uint) ([]model.ApplicationStatusHistory, error)
+}
+
+func NewApplicationService(appRepo repository.ApplicationRepository, jobRepo repository.JobRepository, memberRepo repository.MemberRepository) (ApplicationService, error) {
+	if appRepo == nil {
+		log.Info().Msg("application repo cannot be nil")
+		return nil, errors.New("application repo cannot be nil")
+	}
+	return &Service{
+		appRepo:    appRepo,
+		jobRepo:    jobRepo,
+		memberRepo: memberRepo,
+	}, nil
+}
+
+func (s *Service) ViewApplicationsByJobID(jID uint, uID uint) ([]model.Application, error) {
+
+	jobData, err := s.jobRepo.GetJobByJobID(jID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrJobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.checkCompanyMember(jobData.Cid, uID, model.MemberRoleOwner, model.MemberRoleRecruiter, model.MemberRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	applications, err := s.appRepo.GetApplicationsByJobID(jID)
+	if err != nil {
+		return nil, err
+	}
+
+	return applications, nil
+}
+
+func (s *Service) ViewApplicationByID(aID uint, uID uint) (model.Application, error) {
+
+	application, err := s.appRepo.GetApplicationByID(aID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return model.Application{}, ErrApplicationNotFound
+	}
+	if err != nil {
+		return model.Application{}, err
+	}
+
+	jobData, err := s.jobRepo.GetJobByJobID(application.Jid)
+	if errors.Is(err, repository.ErrNotFound) {
+		return model.Application{}, ErrJobNotFound
+	}
+	if err != nil {
+		return model.Application{}, err
+	}
+
+	_, err = s.checkCompanyMember(jobData.Cid, uID, model.MemberRoleOwner, model.MemberRoleRecruiter, model.MemberRoleViewer)
+	if err != nil {
+		return model.Application{}, err
+	}
+
+	return application, nil
+}
+
+func (s *Service) MoveApplication(aID uint, uID uint, update model.ApplicationStatusUpdate) (model.Application, error) {
+
+	application, err := s.appRepo.GetApplicationByID(aID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return model.Application{}, ErrApplicationNotFound
+	}
+	if err != nil {
+		return model.Application{}, err
+	}
+
+	jobData, err := s.jobRepo.GetJobByJobID(application.Jid)
+	if errors.Is(err, repository.ErrNotFound) {
+		return model.Application{}, ErrJobNotFound
+	}
+	if err != nil {
+		return model.Application{}, err
+	}
+
+	_, err = s.checkCompanyMember(jobData.Cid, uID, model.MemberRoleOwner, model.MemberRoleRecruiter)
+	if err != nil {
+		return model.Application{}, err
+	}
+
+	//only the candidate can withdraw their application
+	if update.Status == model.ApplicationWithdrawn || !canMoveApplication(application.Status, update.Status) {
+		return model.Application{}, ErrInvalidTransition
+	}
+
+	history := model.ApplicationStatusHistory{
+		ApplicationID: application.ID,
+		FromStatus:    application.Status,
+		ToStatus:      update.Status,
+		ChangedBy:     uID,
+		ChangedAt:     time.Now(),
+		Note:          update.Note,
+	}
+	application.Status = update.Status
+
+	application, err = s.appRepo.UpdateApplicationStatus(application, history)
+	if errors.Is(err, repository.ErrNotFound) {
+		return model.Application{}, ErrStatusChanged
+	}
+	if err != nil {
+		return model.Application{}, err
+	}
+
+	return application, nil
+}
+
+func (s *Service) ViewApplicationHistory(aID uint, uID uint) ([]model.ApplicationStatusHistory, error) {
+
+	//fetching the application also checks the user can see it
+	_, err := s.ViewApplicationByID(aID, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.appRepo.GetStatusHistory(aID)
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+func canMoveApplication(from string, to string) bool {
+	for _, v := range applicationTransitions[from] {
+		if v == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: applicationService.go
+//
+// Generated by this command:
+//
+//	mockgen -source=applicationService.go -destination=applicationService_mock.go -package=service
+//
+// Package service is a generated GoMock package.
+package service
+
+import (
+	model "job-portal-api/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockApplicationService is a mock of ApplicationService interface.
+type MockApplicationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationServiceMockRecorder
+}
+
+// MockApplicationServiceMockRecorder is the mock recorder for MockApplicationService.
+type MockApplicationServiceMockRecorder struct {
+	mock *MockApplicationService
+}
+
+// NewMockApplicationService creates a new mock instance.
+func NewMockApplicationService(ctrl *gomock.Controller) *MockApplicationService {
+	mock := &MockApplicationService{ctrl: ctrl}
+	mock.recorder = &MockApplicationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationService) EXPECT() *MockApplicationServiceMockRecorder {
+	return m.recorder
+}
+
+// MoveApplication mocks base method.
+func (m *MockApplicationService) MoveApplication(aID, uID uint, update model.ApplicationStatusUpdate) (model.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveApplication", aID, uID, update)
+	ret0, _ := ret[0].(model.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveApplication indicates an expected call of MoveApplication.
+func (mr *MockApplicationServiceMockRecorder) MoveApplication(aID, uID, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveApplication", reflect.TypeOf((*MockApplicationService)(nil).MoveApplication), aID, uID, update)
+}
+
+// ViewApplicationByID mocks base method.
+func (m *MockApplicationService) ViewApplicationByID(aID, uID uint) (model.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewApplicationByID", aID, uID)
+	ret0, _ := ret[0].(model.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewApplicationByID indicates an expected call of ViewApplicationByID.
+func (mr *MockApplicationServiceMockRecorder) ViewApplicationByID(aID, uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewApplicationByID", reflect.TypeOf((*MockApplicationService)(nil).ViewApplicationByID), aID, uID)
+}
+
+// ViewApplicationHistory mocks base method.
+func (m *MockApplicationService) ViewApplicationHistory(aID, uID uint) ([]model.ApplicationStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewApplicationHistory", aID, uID)
+	ret0, _ := ret[0].([]model.ApplicationStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewApplicationHistory indicates an expected call of ViewApplicationHistory.
+func (mr *MockApplicationServiceMockRecorder) ViewApplicationHistory(aID, uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewApplicationHistory", reflect.TypeOf((*MockApplicationService)(nil).ViewApplicationHistory), aID, uID)
+}
+
+// ViewApplicationsByJobID mocks base method.
+func (m *MockApplicationService) ViewApplicationsByJobID(jID, uID uint) ([]model.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewApplicationsByJobID", jID, uID)
+	ret0, _ := ret[0].([]model.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewApplicationsByJobID indicates an expected call of ViewApplicationsByJobID.
+func (mr *MockApplicationServiceMockRecorder) ViewApplicationsByJobID(jID, uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewApplicationsByJobID", reflect.TypeOf((*MockApplicationService)(nil).ViewApplicationsByJobID), jID, uID)
+}
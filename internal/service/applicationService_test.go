@@ -0,0 +1,314 @@
+package service
+
+import (
+	"errors"
+	"job-portal-api/internal/model"
+	"job-portal-api/internal/repository"
+	"reflect"
+	"testing"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestService_ViewApplicationsByJobID(t *testing.T) {
+	tests := []struct {
+		name             string
+		want             []model.Application
+		wantErr          bool
+		wantErrIs        error
+		mockJob          func() (model.Job, error)
+		mockMember       func() (model.CompanyMember, error)
+		mockApplications func() ([]model.Application, error)
+	}{
+		{
+			name:    "invalid job id",
+			want:    nil,
+			wantErr: true,
+			mockJob: func() (model.Job, error) {
+				return model.Job{}, errors.New("error")
+			},
+		},
+		{
+			name:      "job does not exist",
+			want:      nil,
+			wantErr:   true,
+			wantErrIs: ErrJobNotFound,
+			mockJob: func() (model.Job, error) {
+				return model.Job{}, repository.ErrNotFound
+			},
+		},
+		{
+			name:    "not a company member",
+			want:    nil,
+			wantErr: true,
+			mockJob: func() (model.Job, error) {
+				return model.Job{Cid: 1}, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{}, errors.New("error")
+			},
+		},
+		{
+			name:    "success",
+			want:    []model.Application{{Jid: 1, Name: "soma", Accepted: true}},
+			wantErr: false,
+			mockJob: func() (model.Job, error) {
+				return model.Job{Cid: 1}, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleRecruiter, Status: model.MemberStatusActive}, nil
+			},
+			mockApplications: func() ([]model.Application, error) {
+				return []model.Application{{Jid: 1, Name: "soma", Accepted: true}}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			ma := repository.NewMockApplicationRepository(mc)
+			mj := repository.NewMockJobRepository(mc)
+			mm := repository.NewMockMemberRepository(mc)
+			s, _ := NewApplicationService(ma, mj, mm)
+			if tt.mockJob != nil {
+				mj.EXPECT().GetJobByJobID(gomock.Any()).Return(tt.mockJob()).AnyTimes()
+			}
+			if tt.mockMember != nil {
+				mm.EXPECT().GetMember(gomock.Any(), gomock.Any()).Return(tt.mockMember()).AnyTimes()
+			}
+			if tt.mockApplications != nil {
+				ma.EXPECT().GetApplicationsByJobID(gomock.Any()).Return(tt.mockApplications()).AnyTimes()
+			}
+			got, err := s.ViewApplicationsByJobID(1, 1)
+			if (err != nil) != tt.wantErr || (tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs)) {
+				t.Errorf("Service.ViewApplicationsByJobID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.ViewApplicationsByJobID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_ViewApplicationByID(t *testing.T) {
+	tests := []struct {
+		name            string
+		want            model.Application
+		wantErr         bool
+		wantErrIs       error
+		mockApplication func() (model.Application, error)
+		mockJob         func() (model.Job, error)
+		mockMember      func() (model.CompanyMember, error)
+	}{
+		{
+			name:    "invalid application id",
+			want:    model.Application{},
+			wantErr: true,
+			mockApplication: func() (model.Application, error) {
+				return model.Application{}, errors.New("error")
+			},
+		},
+		{
+			name:      "application does not exist",
+			want:      model.Application{},
+			wantErr:   true,
+			wantErrIs: ErrApplicationNotFound,
+			mockApplication: func() (model.Application, error) {
+				return model.Application{}, repository.ErrNotFound
+			},
+		},
+		{
+			name:      "job of the application does not exist",
+			want:      model.Application{},
+			wantErr:   true,
+			wantErrIs: ErrJobNotFound,
+			mockApplication: func() (model.Application, error) {
+				return model.Application{Jid: 1}, nil
+			},
+			mockJob: func() (model.Job, error) {
+				return model.Job{}, repository.ErrNotFound
+			},
+		},
+		{
+			name:    "not a company member",
+			want:    model.Application{},
+			wantErr: true,
+			mockApplication: func() (model.Application, error) {
+				return model.Application{Jid: 1}, nil
+			},
+			mockJob: func() (model.Job, error) {
+				return model.Job{Cid: 1}, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleRecruiter, Status: model.MemberStatusInvited}, nil
+			},
+		},
+		{
+			name:    "success",
+			want:    model.Application{Jid: 1},
+			wantErr: false,
+			mockApplication: func() (model.Application, error) {
+				return model.Application{Jid: 1}, nil
+			},
+			mockJob: func() (model.Job, error) {
+				return model.Job{Cid: 1}, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleViewer, Status: model.MemberStatusActive}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			ma := repository.NewMockApplicationRepository(mc)
+			mj := repository.NewMockJobRepository(mc)
+			mm := repository.NewMockMemberRepository(mc)
+			s, _ := NewApplicationService(ma, mj, mm)
+			if tt.mockApplication != nil {
+				ma.EXPECT().GetApplicationByID(gomock.Any()).Return(tt.mockApplication()).AnyTimes()
+			}
+			if tt.mockJob != nil {
+				mj.EXPECT().GetJobByJobID(gomock.Any()).Return(tt.mockJob()).AnyTimes()
+			}
+			if tt.mockMember != nil {
+				mm.EXPECT().GetMember(gomock.Any(), gomock.Any()).Return(tt.mockMember()).AnyTimes()
+			}
+			got, err := s.ViewApplicationByID(1, 1)
+			if (err != nil) != tt.wantErr || (tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs)) {
+				t.Errorf("Service.ViewApplicationByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.ViewApplicationByID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_MoveApplication(t *testing.T) {
+	tests := []struct {
+		name            string
+		update          model.ApplicationStatusUpdate
+		want            model.Application
+		wantErr         error
+		mockApplication func() (model.Application, error)
+		mockMember      func() (model.CompanyMember, error)
+		mockUpdate      func() (model.Application, error)
+	}{
+		{
+			name:    "application does not exist",
+			update:  model.ApplicationStatusUpdate{Status: model.ApplicationScreened},
+			want:    model.Application{},
+			wantErr: ErrApplicationNotFound,
+			mockApplication: func() (model.Application, error) {
+				return model.Application{}, repository.ErrNotFound
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleRecruiter, Status: model.MemberStatusActive}, nil
+			},
+		},
+		{
+			name:    "viewer cannot move applications",
+			update:  model.ApplicationStatusUpdate{Status: model.ApplicationScreened},
+			want:    model.Application{},
+			wantErr: ErrNotCompanyMember,
+			mockApplication: func() (model.Application, error) {
+				return model.Application{Jid: 1, Status: model.ApplicationApplied}, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleViewer, Status: model.MemberStatusActive}, nil
+			},
+		},
+		{
+			name:    "skipping a stage is not allowed",
+			update:  model.ApplicationStatusUpdate{Status: model.ApplicationOffered},
+			want:    model.Application{},
+			wantErr: ErrInvalidTransition,
+			mockApplication: func() (model.Application, error) {
+				return model.Application{Jid: 1, Status: model.ApplicationApplied}, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleRecruiter, Status: model.MemberStatusActive}, nil
+			},
+		},
+		{
+			name:    "recruiters cannot withdraw applications",
+			update:  model.ApplicationStatusUpdate{Status: model.ApplicationWithdrawn},
+			want:    model.Application{},
+			wantErr: ErrInvalidTransition,
+			mockApplication: func() (model.Application, error) {
+				return model.Application{Jid: 1, Status: model.ApplicationScreened}, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleRecruiter, Status: model.MemberStatusActive}, nil
+			},
+		},
+		{
+			name:    "final states cannot move",
+			update:  model.ApplicationStatusUpdate{Status: model.ApplicationScreened},
+			want:    model.Application{},
+			wantErr: ErrInvalidTransition,
+			mockApplication: func() (model.Application, error) {
+				return model.Application{Jid: 1, Status: model.ApplicationRejected}, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil
+			},
+		},
+		{
+			name:    "moved by someone else meanwhile",
+			update:  model.ApplicationStatusUpdate{Status: model.ApplicationInterviewing},
+			want:    model.Application{},
+			wantErr: ErrStatusChanged,
+			mockApplication: func() (model.Application, error) {
+				return model.Application{Jid: 1, Status: model.ApplicationScreened}, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleRecruiter, Status: model.MemberStatusActive}, nil
+			},
+			mockUpdate: func() (model.Application, error) {
+				return model.Application{}, repository.ErrNotFound
+			},
+		},
+		{
+			name:    "success",
+			update:  model.ApplicationStatusUpdate{Status: model.ApplicationInterviewing},
+			want:    model.Application{Jid: 1, Status: model.ApplicationInterviewing},
+			wantErr: nil,
+			mockApplication: func() (model.Application, error) {
+				return model.Application{Jid: 1, Status: model.ApplicationScreened}, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleRecruiter, Status: model.MemberStatusActive}, nil
+			},
+			mockUpdate: func() (model.Application, error) {
+				return model.Application{Jid: 1, Status: model.ApplicationInterviewing}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			ma := repository.NewMockApplicationRepository(mc)
+			mj := repository.NewMockJobRepository(mc)
+			mm := repository.NewMockMemberRepository(mc)
+			s, _ := NewApplicationService(ma, mj, mm)
+			ma.EXPECT().GetApplicationByID(gomock.Any()).Return(tt.mockApplication()).AnyTimes()
+			mj.EXPECT().GetJobByJobID(gomock.Any()).Return(model.Job{Cid: 1}, nil).AnyTimes()
+			mm.EXPECT().GetMember(gomock.Any(), gomock.Any()).Return(tt.mockMember()).AnyTimes()
+			if tt.mockUpdate != nil {
+				ma.EXPECT().UpdateApplicationStatus(gomock.Any(), gomock.Any()).Return(tt.mockUpdate()).AnyTimes()
+			}
+			got, err := s.MoveApplication(1, 1, tt.update)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.MoveApplication() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.MoveApplication() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
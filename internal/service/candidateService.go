@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"job-portal-api/internal/cache"
+	"job-portal-api/internal/model"
+	"job-portal-api/internal/repository"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=candidateService.go -destination=candidateService_mock.go -package=service
+type CandidateService interface {
+	SaveProfile(uID uint, details model.NewCandidateProfile) (model.CandidateProfile, error)
+	ViewProfile(uID uint) (model.CandidateProfile, error)
+	ApplyWithProfile(jID uint, uID uint) (model.ProcessedApplication, error)
+	ViewMyApplications(uID uint) ([]model.Application, error)
+	WithdrawApplication(aID uint, uID uint) (model.Application, error)
+}
+
+func NewCandidateService(candidateRepo repository.CandidateRepository, jobRepo repository.JobRepository, appRepo repository.ApplicationRepository,
+	taxonomyRepo repository.TaxonomyRepository, rdb cache.Caching) (CandidateService, error) {
+	if candidateRepo == nil {
+		log.Info().Msg("candidate repo cannot be nil")
+		return nil, errors.New("candidate repo cannot be nil")
+	}
+	return &Service{
+		candidateRepo: candidateRepo,
+		jobRepo:       jobRepo,
+		appRepo:       appRepo,
+		taxonomyRepo:  taxonomyRepo,
+		rdb:           rdb,
+	}, nil
+}
+
+// SaveProfile creates the profile of the candidate or replaces it
+func (s *Service) SaveProfile(uID uint, details model.NewCandidateProfile) (model.CandidateProfile, error) {
+
+	err := s.checkJobReferences(0, model.UpdateJob{
+		Location:        &details.Location,
+		TechnologyStack: &details.TechnologyStack,
+		Qualifications:  &details.Qualifications,
+		Shift:           &details.Shift,
+		Jobtype:         &details.Jobtype,
+	})
+	if err != nil {
+		return model.CandidateProfile{}, err
+	}
+
+	profile := model.CandidateProfile{
+		UserID:         uID,
+		Name:           details.Name,
+		Age:            details.Age,
+		Headline:       details.Headline,
+		Experience:     details.Experience,
+		NoticePeriod:   details.NoticePeriod,
+		ExpectedSalary: details.ExpectedSalary,
+		WorkMode:       details.WorkMode,
+	}
+
+	for _, v := range details.TechnologyStack {
+		profile.TechnologyStack = append(profile.TechnologyStack, model.TechnologyStack{Model: gorm.Model{ID: v}})
+	}
+	for _, v := range details.Qualifications {
+		profile.Qualifications = append(profile.Qualifications, model.Qualification{Model: gorm.Model{ID: v}})
+	}
+	for _, v := range details.Location {
+		profile.Location = append(profile.Location, model.Location{Model: gorm.Model{ID: v}})
+	}
+	for _, v := range details.Shift {
+		profile.Shift = append(profile.Shift, model.Shift{Model: gorm.Model{ID: v}})
+	}
+	for _, v := range details.Jobtype {
+		profile.Jobtype = append(profile.Jobtype, model.JobType{Model: gorm.Model{ID: v}})
+	}
+
+	return s.candidateRepo.SaveProfile(profile)
+}
+
+func (s *Service) ViewProfile(uID uint) (model.CandidateProfile, error) {
+
+	profile, err := s.candidateRepo.GetProfile(uID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return model.CandidateProfile{}, ErrProfileNotFound
+	}
+	if err != nil {
+		return model.CandidateProfile{}, err
+	}
+
+	return profile, nil
+}
+
+// ApplyWithProfile applies the candidate to the job with the data of their stored profile, skills
+// found in their resumes are already part of it
+func (s *Service) ApplyWithProfile(jID uint, uID uint) (model.ProcessedApplication, error) {
+
+	profile, err := s.ViewProfile(uID)
+	if err != nil {
+		return model.ProcessedApplication{}, err
+	}
+
+	application := model.NewUserApplication{
+		Name:   profile.Name,
+		Age:    profile.Age,
+		Jid:    jID,
+		UserID: uID,
+		Jobs:   profileRequestfield(profile),
+	}
+
+	return s.processApplication(context.Background(), 0, application, 0), nil
+}
+
+func (s *Service) ViewMyApplications(uID uint) ([]model.Application, error) {
+
+	applications, err := s.appRepo.GetApplicationsByUserID(uID)
+	if err != nil {
+		return nil, err
+	}
+
+	return applications, nil
+}
+
+// WithdrawApplication withdraws an application of the candidate, applications of other users are
+// reported as missing so their ids are not revealed
+func (s *Service) WithdrawApplication(aID uint, uID uint) (model.Application, error) {
+
+	application, err := s.appRepo.GetApplicationByID(aID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return model.Application{}, ErrApplicationNotFound
+	}
+	if err != nil {
+		return model.Application{}, err
+	}
+	if application.UserID != uID {
+		return model.Application{}, ErrApplicationNotFound
+	}
+
+	if !canMoveApplication(application.Status, model.ApplicationWithdrawn) {
+		return model.Application{}, ErrInvalidTransition
+	}
+
+	history := model.ApplicationStatusHistory{
+		ApplicationID: application.ID,
+		FromStatus:    application.Status,
+		ToStatus:      model.ApplicationWithdrawn,
+		ChangedBy:     uID,
+		ChangedAt:     time.Now(),
+		Note:          "withdrawn by the candidate",
+	}
+	application.Status = model.ApplicationWithdrawn
+
+	application, err = s.appRepo.UpdateApplicationStatus(application, history)
+	if errors.Is(err, repository.ErrNotFound) {
+		return model.Application{}, ErrStatusChanged
+	}
+	if err != nil {
+		return model.Application{}, err
+	}
+
+	return application, nil
+}
+
+// profileRequestfield turns a profile into the details an application is matched on
+func profileRequestfield(profile model.CandidateProfile) model.Requestfield {
+	details := model.Requestfield{
+		NoticePeriod:   profile.NoticePeriod,
+		Experience:     profile.Experience,
+		ExpectedSalary: profile.ExpectedSalary,
+		WorkMode:       profile.WorkMode,
+	}
+
+	for _, v := range profile.Location {
+		details.Location = append(details.Location, v.ID)
+	}
+	for _, v := range profile.TechnologyStack {
+		details.TechnologyStack = append(details.TechnologyStack, v.ID)
+	}
+	for _, v := range profile.Qualifications {
+		details.Qualifications = append(details.Qualifications, v.ID)
+	}
+	for _, v := range profile.Shift {
+		details.Shift = append(details.Shift, v.ID)
+	}
+	for _, v := range profile.Jobtype {
+		details.Jobtype = append(details.Jobtype, v.ID)
+	}
+
+	return details
+}
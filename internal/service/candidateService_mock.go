@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: candidateService.go
+//
+// Generated by this command:
+//
+//	mockgen -source=candidateService.go -destination=candidateService_mock.go -package=service
+//
+// Package service is a generated GoMock package.
+package service
+
+import (
+	model "job-portal-api/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCandidateService is a mock of CandidateService interface.
+type MockCandidateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCandidateServiceMockRecorder
+}
+
+// MockCandidateServiceMockRecorder is the mock recorder for MockCandidateService.
+type MockCandidateServiceMockRecorder struct {
+	mock *MockCandidateService
+}
+
+// NewMockCandidateService creates a new mock instance.
+func NewMockCandidateService(ctrl *gomock.Controller) *MockCandidateService {
+	mock := &MockCandidateService{ctrl: ctrl}
+	mock.recorder = &MockCandidateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCandidateService) EXPECT() *MockCandidateServiceMockRecorder {
+	return m.recorder
+}
+
+// ApplyWithProfile mocks base method.
+func (m *MockCandidateService) ApplyWithProfile(jID, uID uint) (model.ProcessedApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyWithProfile", jID, uID)
+	ret0, _ := ret[0].(model.ProcessedApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyWithProfile indicates an expected call of ApplyWithProfile.
+func (mr *MockCandidateServiceMockRecorder) ApplyWithProfile(jID, uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyWithProfile", reflect.TypeOf((*MockCandidateService)(nil).ApplyWithProfile), jID, uID)
+}
+
+// SaveProfile mocks base method.
+func (m *MockCandidateService) SaveProfile(uID uint, details model.NewCandidateProfile) (model.CandidateProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProfile", uID, details)
+	ret0, _ := ret[0].(model.CandidateProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveProfile indicates an expected call of SaveProfile.
+func (mr *MockCandidateServiceMockRecorder) SaveProfile(uID, details any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProfile", reflect.TypeOf((*MockCandidateService)(nil).SaveProfile), uID, details)
+}
+
+// ViewMyApplications mocks base method.
+func (m *MockCandidateService) ViewMyApplications(uID uint) ([]model.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewMyApplications", uID)
+	ret0, _ := ret[0].([]model.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewMyApplications indicates an expected call of ViewMyApplications.
+func (mr *MockCandidateServiceMockRecorder) ViewMyApplications(uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewMyApplications", reflect.TypeOf((*MockCandidateService)(nil).ViewMyApplications), uID)
+}
+
+// ViewProfile mocks base method.
+func (m *MockCandidateService) ViewProfile(uID uint) (model.CandidateProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewProfile", uID)
+	ret0, _ := ret[0].(model.CandidateProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewProfile indicates an expected call of ViewProfile.
+func (mr *MockCandidateServiceMockRecorder) ViewProfile(uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewProfile", reflect.TypeOf((*MockCandidateService)(nil).ViewProfile), uID)
+}
+
+// WithdrawApplication mocks base method.
+func (m *MockCandidateService) WithdrawApplication(aID, uID uint) (model.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithdrawApplication", aID, uID)
+	ret0, _ := ret[0].(model.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithdrawApplication indicates an expected call of WithdrawApplication.
+func (mr *MockCandidateServiceMockRecorder) WithdrawApplication(aID, uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawApplication", reflect.TypeOf((*MockCandidateService)(nil).WithdrawApplication), aID, uID)
+}
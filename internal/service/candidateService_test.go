@@ -0,0 +1,245 @@
+package service
+
+import (
+	"errors"
+	"job-portal-api/internal/cache"
+	"job-portal-api/internal/model"
+	"job-portal-api/internal/repository"
+	"reflect"
+	"testing"
+
+	gomock "go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestService_SaveProfile(t *testing.T) {
+	tests := []struct {
+		name         string
+		details      model.NewCandidateProfile
+		want         model.CandidateProfile
+		wantErr      bool
+		mockUnusable map[string][]uint
+		mockSave     func() (model.CandidateProfile, error)
+	}{
+		{
+			name:         "unknown skill",
+			details:      model.NewCandidateProfile{Name: "soma", TechnologyStack: []uint{1, 8}},
+			want:         model.CandidateProfile{},
+			wantErr:      true,
+			mockUnusable: map[string][]uint{model.TaxonomyTechnologyStack: {8}},
+		},
+		{
+			name:    "error in saving profile",
+			details: model.NewCandidateProfile{Name: "soma"},
+			want:    model.CandidateProfile{},
+			wantErr: true,
+			mockSave: func() (model.CandidateProfile, error) {
+				return model.CandidateProfile{}, errors.New("error")
+			},
+		},
+		{
+			name:    "success",
+			details: model.NewCandidateProfile{Name: "soma", Experience: 2, TechnologyStack: []uint{1}},
+			want:    model.CandidateProfile{UserID: 1, Name: "soma", Experience: 2},
+			wantErr: false,
+			mockSave: func() (model.CandidateProfile, error) {
+				return model.CandidateProfile{UserID: 1, Name: "soma", Experience: 2}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mcr := repository.NewMockCandidateRepository(mc)
+			mt := repository.NewMockTaxonomyRepository(mc)
+			s, _ := NewCandidateService(mcr, repository.NewMockJobRepository(mc), repository.NewMockApplicationRepository(mc), mt,
+				cache.NewMockCaching(mc))
+			mt.EXPECT().GetUnusableTaxonomyIDs(gomock.Any(), gomock.Any()).DoAndReturn(func(kind string, ids []uint) ([]uint, error) {
+				return tt.mockUnusable[kind], nil
+			}).AnyTimes()
+			if tt.mockSave != nil {
+				mcr.EXPECT().SaveProfile(gomock.Any()).DoAndReturn(func(profile model.CandidateProfile) (model.CandidateProfile, error) {
+					if profile.UserID != 1 || len(profile.TechnologyStack) != len(tt.details.TechnologyStack) {
+						t.Errorf("Service.SaveProfile() saved %v", profile)
+					}
+					return tt.mockSave()
+				})
+			}
+			got, err := s.SaveProfile(1, tt.details)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.SaveProfile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.SaveProfile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_ApplyWithProfile(t *testing.T) {
+	profile := model.CandidateProfile{UserID: 1, Name: "soma", Age: "25", Experience: 2, NoticePeriod: 10,
+		TechnologyStack: []model.TechnologyStack{{Model: gorm.Model{ID: 1}}}, Location: []model.Location{{Model: gorm.Model{ID: 1}}}}
+	jobData := model.Job{
+		MaxNoticePeriod: 30,
+		MinExperience:   1,
+		MaxExperience:   5,
+		Location:        []model.Location{{Model: gorm.Model{ID: 1}}},
+		TechnologyStack: []model.TechnologyStack{{Model: gorm.Model{ID: 1}}},
+		Status:          model.JobStatusPublished,
+	}
+	tests := []struct {
+		name        string
+		wantOutcome string
+		wantErr     error
+		createErr   error
+		mockProfile func() (model.CandidateProfile, error)
+		mockJob     func() (model.Job, error)
+	}{
+		{
+			name:    "candidate has no profile",
+			wantErr: ErrProfileNotFound,
+			mockProfile: func() (model.CandidateProfile, error) {
+				return model.CandidateProfile{}, repository.ErrNotFound
+			},
+		},
+		{
+			name:        "job does not exist",
+			wantOutcome: model.OutcomeInvalidJob,
+			mockProfile: func() (model.CandidateProfile, error) {
+				return profile, nil
+			},
+			mockJob: func() (model.Job, error) {
+				return model.Job{}, repository.ErrNotFound
+			},
+		},
+		{
+			name:        "already applied",
+			wantOutcome: model.OutcomeDuplicate,
+			createErr:   repository.ErrDuplicate,
+			mockProfile: func() (model.CandidateProfile, error) {
+				return profile, nil
+			},
+			mockJob: func() (model.Job, error) {
+				return jobData, nil
+			},
+		},
+		{
+			name:        "application uses the profile",
+			wantOutcome: model.OutcomeAccepted,
+			mockProfile: func() (model.CandidateProfile, error) {
+				return profile, nil
+			},
+			mockJob: func() (model.Job, error) {
+				return jobData, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mcr := repository.NewMockCandidateRepository(mc)
+			mj := repository.NewMockJobRepository(mc)
+			ma := repository.NewMockApplicationRepository(mc)
+			mca := cache.NewMockCaching(mc)
+			s, _ := NewCandidateService(mcr, mj, ma, repository.NewMockTaxonomyRepository(mc), mca)
+			mcr.EXPECT().GetProfile(uint(1)).Return(tt.mockProfile())
+			mca.EXPECT().GetTheCacheData(gomock.Any(), gomock.Any()).Return("", errors.New("cache miss")).AnyTimes()
+			mca.EXPECT().AddToTheCache(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			if tt.mockJob != nil {
+				mj.EXPECT().GetJobByJobID(uint(2)).Return(tt.mockJob())
+			}
+			ma.EXPECT().CreateApplication(gomock.Any()).DoAndReturn(func(application model.Application) (model.Application, error) {
+				want := model.Requestfield{NoticePeriod: 10, Experience: 2, Location: []uint{1}, TechnologyStack: []uint{1}}
+				if application.UserID != 1 || application.Jid != 2 || application.Name != "soma" || !reflect.DeepEqual(application.Details, want) {
+					t.Errorf("Service.ApplyWithProfile() stored %v", application)
+				}
+				if tt.createErr != nil {
+					return model.Application{}, tt.createErr
+				}
+				return model.Application{Model: gorm.Model{ID: 3}}, nil
+			}).AnyTimes()
+			got, err := s.ApplyWithProfile(2, 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.ApplyWithProfile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Outcome != tt.wantOutcome {
+				t.Errorf("Service.ApplyWithProfile() outcome = %v, want %v", got.Outcome, tt.wantOutcome)
+			}
+		})
+	}
+}
+
+func TestService_WithdrawApplication(t *testing.T) {
+	tests := []struct {
+		name      string
+		wantErr   error
+		wantWrite bool
+		updateErr error
+		mockApp   func() (model.Application, error)
+	}{
+		{
+			name:    "application does not exist",
+			wantErr: ErrApplicationNotFound,
+			mockApp: func() (model.Application, error) {
+				return model.Application{}, repository.ErrNotFound
+			},
+		},
+		{
+			name:    "application of another user",
+			wantErr: ErrApplicationNotFound,
+			mockApp: func() (model.Application, error) {
+				return model.Application{Model: gorm.Model{ID: 3}, UserID: 2, Status: model.ApplicationApplied}, nil
+			},
+		},
+		{
+			name:    "application already rejected",
+			wantErr: ErrInvalidTransition,
+			mockApp: func() (model.Application, error) {
+				return model.Application{Model: gorm.Model{ID: 3}, UserID: 1, Status: model.ApplicationRejected}, nil
+			},
+		},
+		{
+			name:      "application moved meanwhile",
+			wantErr:   ErrStatusChanged,
+			wantWrite: true,
+			updateErr: repository.ErrNotFound,
+			mockApp: func() (model.Application, error) {
+				return model.Application{Model: gorm.Model{ID: 3}, UserID: 1, Status: model.ApplicationScreened}, nil
+			},
+		},
+		{
+			name:      "success",
+			wantWrite: true,
+			mockApp: func() (model.Application, error) {
+				return model.Application{Model: gorm.Model{ID: 3}, UserID: 1, Status: model.ApplicationScreened}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			ma := repository.NewMockApplicationRepository(mc)
+			s, _ := NewCandidateService(repository.NewMockCandidateRepository(mc), repository.NewMockJobRepository(mc), ma,
+				repository.NewMockTaxonomyRepository(mc), cache.NewMockCaching(mc))
+			ma.EXPECT().GetApplicationByID(uint(3)).Return(tt.mockApp())
+			if tt.wantWrite {
+				ma.EXPECT().UpdateApplicationStatus(gomock.Any(), gomock.Any()).DoAndReturn(func(application model.Application, history model.ApplicationStatusHistory) (model.Application, error) {
+					if history.FromStatus != model.ApplicationScreened || history.ToStatus != model.ApplicationWithdrawn || history.ChangedBy != 1 {
+						t.Errorf("Service.WithdrawApplication() history = %v", history)
+					}
+					return application, tt.updateErr
+				})
+			}
+			got, err := s.WithdrawApplication(3, 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.WithdrawApplication() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && got.Status != model.ApplicationWithdrawn {
+				t.Errorf("Service.WithdrawApplication() status = %v, want %v", got.Status, model.ApplicationWithdrawn)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"job-portal-api/internal/cache"
 	"job-portal-api/internal/model"
 	"job-portal-api/internal/repository"
 
@@ -10,22 +11,36 @@ import (
 
 //go:generate mockgen -source=companyService.go -destination=companyService_mock.go -package=service
 type ComapnyService interface {
-	AddingCompany(company model.AddCompany) (model.Company, error)
+	AddingCompany(company model.AddCompany, uID uint) (model.Company, error)
 	ViewCompanyById(Id uint64) (model.Company, error)
 	ViewAllCompanies() ([]model.Company, error)
+	InviteMember(cID uint, uID uint, invite model.InviteMember) (model.CompanyMember, error)
+	AcceptInvite(cID uint, uID uint) (model.CompanyMember, error)
+	RemoveMember(cID uint, uID uint, memberID uint) error
+	ViewMembers(cID uint, uID uint) ([]model.CompanyMember, error)
+	UpdateCompany(cID uint, uID uint, update model.UpdateCompany) (model.Company, error)
+	DeleteCompany(cID uint, uID uint) error
 }
 
-func NewCompanyService(comapnyRepo repository.ComapnyRepo) (ComapnyService, error) {
+func NewCompanyService(comapnyRepo repository.ComapnyRepo, memberRepo repository.MemberRepository, userRepo repository.UserRepository,
+	rdb cache.Caching) (ComapnyService, error) {
 	if comapnyRepo == nil {
 		log.Info().Msg("comapny service cannot be nil")
 		return nil, errors.New("company service cannot be nil")
 	}
+	if memberRepo == nil {
+		log.Info().Msg("member repo cannot be nil")
+		return nil, errors.New("member repo cannot be nil")
+	}
 	return &Service{
 		comapnayRepo: comapnyRepo,
+		memberRepo:   memberRepo,
+		userRepo:     userRepo,
+		rdb:          rdb,
 	}, nil
 }
 
-func (s *Service) AddingCompany(company model.AddCompany) (model.Company, error) {
+func (s *Service) AddingCompany(company model.AddCompany, uID uint) (model.Company, error) {
 
 	companyData := model.Company{
 		CompanyName: company.CompanyName,
@@ -33,7 +48,7 @@ func (s *Service) AddingCompany(company model.AddCompany) (model.Company, error)
 		Domain:      company.Domain,
 	}
 
-	companyData, err := s.comapnayRepo.CreateComapny(companyData)
+	companyData, err := s.comapnayRepo.CreateComapny(companyData, uID)
 	if err != nil {
 		return model.Company{}, err
 	}
@@ -59,3 +74,163 @@ func (s *Service) ViewAllCompanies() ([]model.Company, error) {
 
 	return companiesData, nil
 }
+
+func (s *Service) InviteMember(cID uint, uID uint, invite model.InviteMember) (model.CompanyMember, error) {
+
+	_, err := s.checkCompanyMember(cID, uID, model.MemberRoleOwner)
+	if err != nil {
+		return model.CompanyMember{}, err
+	}
+
+	userData, err := s.userRepo.CheckUser(invite.EmailID)
+	if err != nil {
+		return model.CompanyMember{}, err
+	}
+
+	_, err = s.memberRepo.GetMember(cID, userData.ID)
+	if err == nil {
+		return model.CompanyMember{}, errors.New("user is already a member of the company")
+	}
+
+	member := model.CompanyMember{
+		CompanyID: cID,
+		UserID:    userData.ID,
+		Role:      invite.Role,
+		Status:    model.MemberStatusInvited,
+		InvitedBy: uID,
+	}
+
+	member, err = s.memberRepo.AddMember(member)
+	if err != nil {
+		return model.CompanyMember{}, err
+	}
+
+	return member, nil
+}
+
+func (s *Service) AcceptInvite(cID uint, uID uint) (model.CompanyMember, error) {
+
+	member, err := s.memberRepo.GetMember(cID, uID)
+	if err != nil {
+		return model.CompanyMember{}, err
+	}
+
+	if member.Status != model.MemberStatusInvited {
+		return model.CompanyMember{}, errors.New("no pending invitation for the user")
+	}
+
+	member.Status = model.MemberStatusActive
+
+	member, err = s.memberRepo.UpdateMember(member)
+	if err != nil {
+		return model.CompanyMember{}, err
+	}
+
+	return member, nil
+}
+
+func (s *Service) RemoveMember(cID uint, uID uint, memberID uint) error {
+
+	//members can always leave on their own, removing someone else needs an owner
+	if uID != memberID {
+		_, err := s.checkCompanyMember(cID, uID, model.MemberRoleOwner)
+		if err != nil {
+			return err
+		}
+	}
+
+	member, err := s.memberRepo.GetMember(cID, memberID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == model.MemberRoleOwner && member.Status == model.MemberStatusActive {
+		members, err := s.memberRepo.GetMembersByCompanyID(cID)
+		if err != nil {
+			return err
+		}
+
+		owners := 0
+		for _, v := range members {
+			if v.Role == model.MemberRoleOwner && v.Status == model.MemberStatusActive {
+				owners++
+			}
+		}
+		if owners <= 1 {
+			return errors.New("company must have at least one owner")
+		}
+	}
+
+	return s.memberRepo.RemoveMember(cID, memberID)
+}
+
+func (s *Service) ViewMembers(cID uint, uID uint) ([]model.CompanyMember, error) {
+
+	_, err := s.checkCompanyMember(cID, uID, model.MemberRoleOwner, model.MemberRoleRecruiter, model.MemberRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.memberRepo.GetMembersByCompanyID(cID)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (s *Service) UpdateCompany(cID uint, uID uint, update model.UpdateCompany) (model.Company, error) {
+
+	_, err := s.checkCompanyMember(cID, uID, model.MemberRoleOwner)
+	if err != nil {
+		return model.Company{}, err
+	}
+
+	companyData, err := s.comapnayRepo.GetCompanyByID(uint64(cID))
+	if errors.Is(err, repository.ErrNotFound) {
+		return model.Company{}, ErrCompanyNotFound
+	}
+	if err != nil {
+		return model.Company{}, err
+	}
+
+	if update.CompanyName != nil {
+		companyData.CompanyName = *update.CompanyName
+	}
+	if update.Address != nil {
+		companyData.Address = *update.Address
+	}
+	if update.Domain != nil {
+		companyData.Domain = *update.Domain
+	}
+
+	companyData, err = s.comapnayRepo.UpdateCompany(companyData)
+	if err != nil {
+		return model.Company{}, err
+	}
+
+	return companyData, nil
+}
+
+// DeleteCompany removes the company, its open jobs are closed and no longer listed
+func (s *Service) DeleteCompany(cID uint, uID uint) error {
+
+	_, err := s.checkCompanyMember(cID, uID, model.MemberRoleOwner)
+	if err != nil {
+		return err
+	}
+
+	jobIDs, err := s.comapnayRepo.DeleteCompany(cID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrCompanyNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, jID := range jobIDs {
+		s.forgetJob(jID)
+	}
+
+	return nil
+}
@@ -38,19 +38,92 @@ func (m *MockComapnyService) EXPECT() *MockComapnyServiceMockRecorder {
 	return m.recorder
 }
 
+// AcceptInvite mocks base method.
+func (m *MockComapnyService) AcceptInvite(cID, uID uint) (model.CompanyMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvite", cID, uID)
+	ret0, _ := ret[0].(model.CompanyMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvite indicates an expected call of AcceptInvite.
+func (mr *MockComapnyServiceMockRecorder) AcceptInvite(cID, uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvite", reflect.TypeOf((*MockComapnyService)(nil).AcceptInvite), cID, uID)
+}
+
 // AddingCompany mocks base method.
-func (m *MockComapnyService) AddingCompany(company model.AddCompany) (model.Company, error) {
+func (m *MockComapnyService) AddingCompany(company model.AddCompany, uID uint) (model.Company, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddingCompany", company)
+	ret := m.ctrl.Call(m, "AddingCompany", company, uID)
 	ret0, _ := ret[0].(model.Company)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddingCompany indicates an expected call of AddingCompany.
-func (mr *MockComapnyServiceMockRecorder) AddingCompany(company any) *gomock.Call {
+func (mr *MockComapnyServiceMockRecorder) AddingCompany(company, uID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddingCompany", reflect.TypeOf((*MockComapnyService)(nil).AddingCompany), company)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddingCompany", reflect.TypeOf((*MockComapnyService)(nil).AddingCompany), company, uID)
+}
+
+// DeleteCompany mocks base method.
+func (m *MockComapnyService) DeleteCompany(cID, uID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCompany", cID, uID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCompany indicates an expected call of DeleteCompany.
+func (mr *MockComapnyServiceMockRecorder) DeleteCompany(cID, uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompany", reflect.TypeOf((*MockComapnyService)(nil).DeleteCompany), cID, uID)
+}
+
+// InviteMember mocks base method.
+func (m *MockComapnyService) InviteMember(cID, uID uint, invite model.InviteMember) (model.CompanyMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteMember", cID, uID, invite)
+	ret0, _ := ret[0].(model.CompanyMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteMember indicates an expected call of InviteMember.
+func (mr *MockComapnyServiceMockRecorder) InviteMember(cID, uID, invite any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteMember", reflect.TypeOf((*MockComapnyService)(nil).InviteMember), cID, uID, invite)
+}
+
+// RemoveMember mocks base method.
+func (m *MockComapnyService) RemoveMember(cID, uID, memberID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", cID, uID, memberID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockComapnyServiceMockRecorder) RemoveMember(cID, uID, memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockComapnyService)(nil).RemoveMember), cID, uID, memberID)
+}
+
+// UpdateCompany mocks base method.
+func (m *MockComapnyService) UpdateCompany(cID, uID uint, update model.UpdateCompany) (model.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCompany", cID, uID, update)
+	ret0, _ := ret[0].(model.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCompany indicates an expected call of UpdateCompany.
+func (mr *MockComapnyServiceMockRecorder) UpdateCompany(cID, uID, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCompany", reflect.TypeOf((*MockComapnyService)(nil).UpdateCompany), cID, uID, update)
 }
 
 // ViewAllCompanies mocks base method.
@@ -82,3 +155,18 @@ func (mr *MockComapnyServiceMockRecorder) ViewCompanyById(Id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewCompanyById", reflect.TypeOf((*MockComapnyService)(nil).ViewCompanyById), Id)
 }
+
+// ViewMembers mocks base method.
+func (m *MockComapnyService) ViewMembers(cID, uID uint) ([]model.CompanyMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewMembers", cID, uID)
+	ret0, _ := ret[0].([]model.CompanyMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewMembers indicates an expected call of ViewMembers.
+func (mr *MockComapnyServiceMockRecorder) ViewMembers(cID, uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewMembers", reflect.TypeOf((*MockComapnyService)(nil).ViewMembers), cID, uID)
+}
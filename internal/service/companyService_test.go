@@ -2,12 +2,14 @@ package service
 
 import (
 	"errors"
+	"job-portal-api/internal/cache"
 	"job-portal-api/internal/model"
 	"job-portal-api/internal/repository"
 	"reflect"
 	"testing"
 
 	gomock "go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
 func TestService_AddingCompany(t *testing.T) {
@@ -44,11 +46,11 @@ func TestService_AddingCompany(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mc := gomock.NewController(t)
 			ms := repository.NewMockComapnyRepo(mc)
-			s,_:=NewCompanyService(ms)
+			s,_:=NewCompanyService(ms, repository.NewMockMemberRepository(mc), repository.NewMockUserRepository(mc), cache.NewMockCaching(mc))
 			if tt.mockUserResponse != nil {
-				ms.EXPECT().CreateComapny(gomock.Any()).Return(tt.mockUserResponse()).AnyTimes()
+				ms.EXPECT().CreateComapny(gomock.Any(), gomock.Any()).Return(tt.mockUserResponse()).AnyTimes()
 			}
-			got, err := s.AddingCompany(tt.args.company)
+			got, err := s.AddingCompany(tt.args.company, 1)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Service.UserSignup() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -88,7 +90,7 @@ func TestService_ViewAllCompanies(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mc := gomock.NewController(t)
 			ms := repository.NewMockComapnyRepo(mc)
-			s,_:=NewCompanyService(ms)
+			s,_:=NewCompanyService(ms, repository.NewMockMemberRepository(mc), repository.NewMockUserRepository(mc), cache.NewMockCaching(mc))
 			if tt.mockUserResponse != nil {
 				ms.EXPECT().GetAllCompanies().Return(tt.mockUserResponse()).AnyTimes()
 			}
@@ -138,7 +140,7 @@ func TestService_ViewCompanyById(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mc := gomock.NewController(t)
 			ms := repository.NewMockComapnyRepo(mc)
-			s,_:=NewCompanyService(ms)
+			s,_:=NewCompanyService(ms, repository.NewMockMemberRepository(mc), repository.NewMockUserRepository(mc), cache.NewMockCaching(mc))
 			if tt.mockUserResponse != nil {
 				ms.EXPECT().GetCompanyByID(gomock.Any()).Return(tt.mockUserResponse()).AnyTimes()
 			}
@@ -153,3 +155,358 @@ func TestService_ViewCompanyById(t *testing.T) {
 		})
 	}
 }
+
+func TestService_InviteMember(t *testing.T) {
+	type args struct {
+		cID    uint
+		uID    uint
+		invite model.InviteMember
+	}
+	tests := []struct {
+		name         string
+		args         args
+		want         model.CompanyMember
+		wantErr      bool
+		mockInviter  func() (model.CompanyMember, error)
+		mockUser     func() (model.User, error)
+		mockExisting func() (model.CompanyMember, error)
+		mockAdd      func() (model.CompanyMember, error)
+	}{
+		{
+			name:    "inviter is not an owner",
+			args:    args{cID: 1, uID: 1, invite: model.InviteMember{EmailID: "abc@gmail.com", Role: model.MemberRoleRecruiter}},
+			want:    model.CompanyMember{},
+			wantErr: true,
+			mockInviter: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleRecruiter, Status: model.MemberStatusActive}, nil
+			},
+		},
+		{
+			name:    "invited user does not exist",
+			args:    args{cID: 1, uID: 1, invite: model.InviteMember{EmailID: "abc@gmail.com", Role: model.MemberRoleRecruiter}},
+			want:    model.CompanyMember{},
+			wantErr: true,
+			mockInviter: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil
+			},
+			mockUser: func() (model.User, error) {
+				return model.User{}, errors.New("error")
+			},
+		},
+		{
+			name:    "success",
+			args:    args{cID: 1, uID: 1, invite: model.InviteMember{EmailID: "abc@gmail.com", Role: model.MemberRoleRecruiter}},
+			want:    model.CompanyMember{CompanyID: 1, UserID: 2, Role: model.MemberRoleRecruiter, Status: model.MemberStatusInvited, InvitedBy: 1},
+			wantErr: false,
+			mockInviter: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil
+			},
+			mockUser: func() (model.User, error) {
+				return model.User{Model: gorm.Model{ID: 2}}, nil
+			},
+			mockExisting: func() (model.CompanyMember, error) {
+				return model.CompanyMember{}, errors.New("error")
+			},
+			mockAdd: func() (model.CompanyMember, error) {
+				return model.CompanyMember{CompanyID: 1, UserID: 2, Role: model.MemberRoleRecruiter, Status: model.MemberStatusInvited, InvitedBy: 1}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			ms := repository.NewMockComapnyRepo(mc)
+			mm := repository.NewMockMemberRepository(mc)
+			mu := repository.NewMockUserRepository(mc)
+			s, _ := NewCompanyService(ms, mm, mu, cache.NewMockCaching(mc))
+			if tt.mockInviter != nil {
+				mm.EXPECT().GetMember(tt.args.cID, tt.args.uID).Return(tt.mockInviter()).AnyTimes()
+			}
+			if tt.mockUser != nil {
+				mu.EXPECT().CheckUser(gomock.Any()).Return(tt.mockUser()).AnyTimes()
+			}
+			if tt.mockExisting != nil {
+				mm.EXPECT().GetMember(tt.args.cID, uint(2)).Return(tt.mockExisting()).AnyTimes()
+			}
+			if tt.mockAdd != nil {
+				mm.EXPECT().AddMember(gomock.Any()).Return(tt.mockAdd()).AnyTimes()
+			}
+			got, err := s.InviteMember(tt.args.cID, tt.args.uID, tt.args.invite)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.InviteMember() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.InviteMember() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_AcceptInvite(t *testing.T) {
+	tests := []struct {
+		name       string
+		want       model.CompanyMember
+		wantErr    bool
+		mockMember func() (model.CompanyMember, error)
+		mockUpdate func() (model.CompanyMember, error)
+	}{
+		{
+			name:    "no invitation",
+			want:    model.CompanyMember{},
+			wantErr: true,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{}, errors.New("error")
+			},
+		},
+		{
+			name:    "already active",
+			want:    model.CompanyMember{},
+			wantErr: true,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Status: model.MemberStatusActive}, nil
+			},
+		},
+		{
+			name:    "success",
+			want:    model.CompanyMember{Status: model.MemberStatusActive},
+			wantErr: false,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Status: model.MemberStatusInvited}, nil
+			},
+			mockUpdate: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Status: model.MemberStatusActive}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mm := repository.NewMockMemberRepository(mc)
+			s, _ := NewCompanyService(repository.NewMockComapnyRepo(mc), mm, repository.NewMockUserRepository(mc), cache.NewMockCaching(mc))
+			if tt.mockMember != nil {
+				mm.EXPECT().GetMember(gomock.Any(), gomock.Any()).Return(tt.mockMember()).AnyTimes()
+			}
+			if tt.mockUpdate != nil {
+				mm.EXPECT().UpdateMember(gomock.Any()).Return(tt.mockUpdate()).AnyTimes()
+			}
+			got, err := s.AcceptInvite(1, 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.AcceptInvite() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.AcceptInvite() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_RemoveMember(t *testing.T) {
+	tests := []struct {
+		name        string
+		uID         uint
+		memberID    uint
+		wantErr     bool
+		mockOwner   func() (model.CompanyMember, error)
+		mockMember  func() (model.CompanyMember, error)
+		mockMembers func() ([]model.CompanyMember, error)
+		mockRemove  func() error
+	}{
+		{
+			name:     "remover is not an owner",
+			uID:      1,
+			memberID: 2,
+			wantErr:  true,
+			mockOwner: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleViewer, Status: model.MemberStatusActive}, nil
+			},
+		},
+		{
+			name:     "last owner cannot leave",
+			uID:      1,
+			memberID: 1,
+			wantErr:  true,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{UserID: 1, Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil
+			},
+			mockMembers: func() ([]model.CompanyMember, error) {
+				return []model.CompanyMember{{UserID: 1, Role: model.MemberRoleOwner, Status: model.MemberStatusActive}}, nil
+			},
+		},
+		{
+			name:     "success",
+			uID:      1,
+			memberID: 2,
+			wantErr:  false,
+			mockOwner: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{UserID: 2, Role: model.MemberRoleRecruiter, Status: model.MemberStatusActive}, nil
+			},
+			mockRemove: func() error {
+				return nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mm := repository.NewMockMemberRepository(mc)
+			s, _ := NewCompanyService(repository.NewMockComapnyRepo(mc), mm, repository.NewMockUserRepository(mc), cache.NewMockCaching(mc))
+			if tt.mockOwner != nil {
+				mm.EXPECT().GetMember(gomock.Any(), tt.uID).Return(tt.mockOwner()).AnyTimes()
+			}
+			if tt.mockMember != nil {
+				mm.EXPECT().GetMember(gomock.Any(), tt.memberID).Return(tt.mockMember()).AnyTimes()
+			}
+			if tt.mockMembers != nil {
+				mm.EXPECT().GetMembersByCompanyID(gomock.Any()).Return(tt.mockMembers()).AnyTimes()
+			}
+			if tt.mockRemove != nil {
+				mm.EXPECT().RemoveMember(gomock.Any(), gomock.Any()).Return(tt.mockRemove()).AnyTimes()
+			}
+			err := s.RemoveMember(1, tt.uID, tt.memberID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.RemoveMember() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_UpdateCompany(t *testing.T) {
+	name := "tek systems"
+	tests := []struct {
+		name        string
+		update      model.UpdateCompany
+		want        model.Company
+		wantErr     error
+		mockMember  func() (model.CompanyMember, error)
+		mockCompany func() (model.Company, error)
+	}{
+		{
+			name:    "recruiter cannot update the company",
+			want:    model.Company{},
+			wantErr: ErrNotCompanyMember,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleRecruiter, Status: model.MemberStatusActive}, nil
+			},
+		},
+		{
+			name:    "company deleted",
+			want:    model.Company{},
+			wantErr: ErrCompanyNotFound,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil
+			},
+			mockCompany: func() (model.Company, error) {
+				return model.Company{}, repository.ErrNotFound
+			},
+		},
+		{
+			name:    "only given fields are changed",
+			update:  model.UpdateCompany{CompanyName: &name},
+			want:    model.Company{CompanyName: "tek systems", Address: "bangalore"},
+			wantErr: nil,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil
+			},
+			mockCompany: func() (model.Company, error) {
+				return model.Company{CompanyName: "tek", Address: "bangalore"}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			ms := repository.NewMockComapnyRepo(mc)
+			mm := repository.NewMockMemberRepository(mc)
+			s, _ := NewCompanyService(ms, mm, repository.NewMockUserRepository(mc), cache.NewMockCaching(mc))
+			mm.EXPECT().GetMember(gomock.Any(), gomock.Any()).Return(tt.mockMember()).AnyTimes()
+			if tt.mockCompany != nil {
+				ms.EXPECT().GetCompanyByID(gomock.Any()).Return(tt.mockCompany()).AnyTimes()
+			}
+			ms.EXPECT().UpdateCompany(gomock.Any()).DoAndReturn(func(company model.Company) (model.Company, error) {
+				return company, nil
+			}).AnyTimes()
+			got, err := s.UpdateCompany(1, 1, tt.update)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.UpdateCompany() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.UpdateCompany() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_DeleteCompany(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantErr    error
+		mockMember func() (model.CompanyMember, error)
+		mockDelete func() ([]uint, error)
+		wantForget []uint
+	}{
+		{
+			name:    "user is not the owner",
+			wantErr: ErrNotCompanyMember,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleViewer, Status: model.MemberStatusActive}, nil
+			},
+		},
+		{
+			name:    "company already deleted",
+			wantErr: ErrCompanyNotFound,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil
+			},
+			mockDelete: func() ([]uint, error) {
+				return nil, repository.ErrNotFound
+			},
+		},
+		{
+			name:    "success",
+			wantErr: nil,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil
+			},
+			mockDelete: func() ([]uint, error) {
+				return nil, nil
+			},
+		},
+		{
+			name:    "closed jobs are removed from the cache",
+			wantErr: nil,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil
+			},
+			mockDelete: func() ([]uint, error) {
+				return []uint{3, 5}, nil
+			},
+			wantForget: []uint{3, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			ms := repository.NewMockComapnyRepo(mc)
+			mm := repository.NewMockMemberRepository(mc)
+			mca := cache.NewMockCaching(mc)
+			s, _ := NewCompanyService(ms, mm, repository.NewMockUserRepository(mc), mca)
+			mm.EXPECT().GetMember(gomock.Any(), gomock.Any()).Return(tt.mockMember()).AnyTimes()
+			if tt.mockDelete != nil {
+				ms.EXPECT().DeleteCompany(gomock.Any()).Return(tt.mockDelete()).AnyTimes()
+			}
+			for _, jID := range tt.wantForget {
+				mca.EXPECT().DeleteTheCacheData(gomock.Any(), jID).Return(nil)
+			}
+			err := s.DeleteCompany(1, 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.DeleteCompany() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
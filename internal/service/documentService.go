@@ -0,0 +1,288 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"job-portal-api/internal/model"
+	"job-portal-api/internal/repository"
+	"job-portal-api/internal/storage"
+	"job-portal-api/internal/textextract"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// documentType is what a file with an accepted extension must start with and the content type it is served with
+type documentType struct {
+	contentType string
+	magic       []byte
+}
+
+// documentTypes lists the accepted documents by extension, a docx file is a zip archive
+var documentTypes = map[string]documentType{
+	".pdf":  {contentType: model.ContentTypePDF, magic: []byte("%PDF-")},
+	".docx": {contentType: model.ContentTypeDOCX, magic: []byte("PK\x03\x04")},
+}
+
+//go:generate mockgen -source=documentService.go -destination=documentService_mock.go -package=service
+type DocumentService interface {
+	UploadDocument(ctx context.Context, uID uint, upload model.NewDocument, file io.Reader) (model.Document, error)
+	ViewApplicationDocuments(aID uint, uID uint) ([]model.DocumentLink, error)
+	OpenDocument(ctx context.Context, dID uint, expires int64, signature string) (model.Document, io.ReadCloser, error)
+}
+
+func NewDocumentService(docRepo repository.DocumentRepository, appRepo repository.ApplicationRepository, jobRepo repository.JobRepository,
+	memberRepo repository.MemberRepository, candidateRepo repository.CandidateRepository, taxonomyRepo repository.TaxonomyRepository,
+	store storage.Storage, signer *storage.Signer, extractor textextract.Extractor, maxDocumentSize int64) (DocumentService, error) {
+	if docRepo == nil {
+		log.Info().Msg("document repo cannot be nil")
+		return nil, errors.New("document repo cannot be nil")
+	}
+	if store == nil || signer == nil || extractor == nil {
+		log.Info().Msg("document storage cannot be nil")
+		return nil, errors.New("document storage cannot be nil")
+	}
+	return &Service{
+		docRepo:         docRepo,
+		appRepo:         appRepo,
+		jobRepo:         jobRepo,
+		memberRepo:      memberRepo,
+		candidateRepo:   candidateRepo,
+		taxonomyRepo:    taxonomyRepo,
+		storage:         store,
+		signer:          signer,
+		extractor:       extractor,
+		maxDocumentSize: maxDocumentSize,
+	}, nil
+}
+
+// UploadDocument stores a pdf or docx file of the candidate, the file has to start the way
+// its extension says so a renamed file is refused. The skills found in a resume are kept on the
+// document and a resume of the profile also adds them to the profile
+func (s *Service) UploadDocument(ctx context.Context, uID uint, upload model.NewDocument, file io.Reader) (model.Document, error) {
+
+	if upload.Size > s.maxDocumentSize {
+		return model.Document{}, ErrDocumentTooLarge
+	}
+
+	ext := strings.ToLower(filepath.Ext(upload.FileName))
+	docType, ok := documentTypes[ext]
+	if !ok {
+		return model.Document{}, ErrInvalidDocument
+	}
+
+	header := make([]byte, len(docType.magic))
+	_, err := io.ReadFull(file, header)
+	if err != nil || !bytes.Equal(header, docType.magic) {
+		return model.Document{}, ErrInvalidDocument
+	}
+
+	if upload.ApplicationID != nil {
+		application, err := s.appRepo.GetApplicationByID(*upload.ApplicationID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return model.Document{}, ErrApplicationNotFound
+		}
+		if err != nil {
+			return model.Document{}, err
+		}
+		if application.UserID != uID {
+			return model.Document{}, ErrApplicationNotFound
+		}
+	}
+
+	key := fmt.Sprintf("%d/%s%s", uID, uuid.NewString(), ext)
+
+	//the declared size cannot be trusted so one byte past the limit is read to tell an oversized file
+	size, err := s.storage.Save(ctx, key, io.LimitReader(io.MultiReader(bytes.NewReader(header), file), s.maxDocumentSize+1))
+	if err != nil {
+		log.Error().Err(err).Msg("error in storing document")
+		return model.Document{}, errors.New("could not store the document")
+	}
+	if size > s.maxDocumentSize {
+		s.deleteStoredDocument(ctx, key)
+		return model.Document{}, ErrDocumentTooLarge
+	}
+
+	document, err := s.docRepo.CreateDocument(model.Document{
+		UserID:        uID,
+		ApplicationID: upload.ApplicationID,
+		Kind:          upload.Kind,
+		FileName:      filepath.Base(upload.FileName),
+		ContentType:   docType.contentType,
+		Size:          size,
+		StorageKey:    key,
+	})
+	if err != nil {
+		s.deleteStoredDocument(ctx, key)
+		return model.Document{}, err
+	}
+
+	if document.Kind == model.DocumentResume {
+		document.Skills = s.addResumeSkills(ctx, document)
+	}
+
+	return document, nil
+}
+
+// addResumeSkills detects the skills of a stored resume, failing to do so never fails the upload
+// so errors are only logged
+func (s *Service) addResumeSkills(ctx context.Context, document model.Document) model.DetectedSkills {
+
+	skills, err := s.detectResumeSkills(ctx, document)
+	if err != nil {
+		log.Error().Err(err).Uint("document id", document.ID).Msg("error in detecting resume skills")
+		return model.DetectedSkills{}
+	}
+	if len(skills.TechnologyStack) == 0 && len(skills.Qualifications) == 0 {
+		return skills
+	}
+
+	err = s.docRepo.SetDocumentSkills(document.ID, skills)
+	if err != nil {
+		log.Error().Err(err).Uint("document id", document.ID).Msg("error in saving resume skills")
+	}
+
+	if document.ApplicationID == nil {
+		err = s.candidateRepo.AddProfileSkills(document.UserID, skills)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			log.Error().Err(err).Uint("user id", document.UserID).Msg("error in adding resume skills to the profile")
+		}
+	}
+
+	return skills
+}
+
+func (s *Service) detectResumeSkills(ctx context.Context, document model.Document) (model.DetectedSkills, error) {
+
+	file, err := s.storage.Open(ctx, document.StorageKey)
+	if err != nil {
+		return model.DetectedSkills{}, err
+	}
+	defer file.Close()
+
+	text, err := s.extractor.Extract(document.ContentType, file)
+	if err != nil {
+		return model.DetectedSkills{}, err
+	}
+
+	stack, err := s.taxonomyRepo.GetTaxonomies(model.TaxonomyTechnologyStack, false)
+	if err != nil {
+		return model.DetectedSkills{}, err
+	}
+
+	qualifications, err := s.taxonomyRepo.GetTaxonomies(model.TaxonomyQualification, false)
+	if err != nil {
+		return model.DetectedSkills{}, err
+	}
+
+	return model.DetectedSkills{
+		TechnologyStack: findTaxonomies(text, stack),
+		Qualifications:  findTaxonomies(text, qualifications),
+	}, nil
+}
+
+// findTaxonomies returns the ids of the taxonomy values named in the text, a name only counts as a
+// whole word so java is not found in javascript
+func findTaxonomies(text string, taxonomies []model.Taxonomy) []uint {
+	text = strings.ToLower(text)
+
+	var found []uint
+	for _, v := range taxonomies {
+		name := strings.ToLower(strings.TrimSpace(v.Name))
+		if name != "" && containsWord(text, name) {
+			found = append(found, v.ID)
+		}
+	}
+
+	return found
+}
+
+func containsWord(text string, word string) bool {
+	for from := 0; from < len(text); {
+		i := strings.Index(text[from:], word)
+		if i < 0 {
+			return false
+		}
+		start := from + i
+		end := start + len(word)
+
+		before := start == 0 || !isWordByte(text[start-1])
+		after := end == len(text) || !isWordByte(text[end])
+		if before && after {
+			return true
+		}
+		from = start + 1
+	}
+	return false
+}
+
+// isWordByte reports whether the byte of the lower cased text is a letter or a digit
+func isWordByte(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+}
+
+// ViewApplicationDocuments lists the documents of an application to the members of the company
+// with a signed link to download each of them
+func (s *Service) ViewApplicationDocuments(aID uint, uID uint) ([]model.DocumentLink, error) {
+
+	//fetching the application also checks the user can see it
+	application, err := s.ViewApplicationByID(aID, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := s.docRepo.GetApplicationDocuments(application.ID, application.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	links := make([]model.DocumentLink, 0, len(documents))
+	for _, v := range documents {
+		expires, signature := s.signer.Sign(v.ID, now)
+		links = append(links, model.DocumentLink{
+			Document:  v,
+			URL:       fmt.Sprintf("/api/documents/%d/download?expires=%d&signature=%s", v.ID, expires.Unix(), signature),
+			ExpiresAt: expires,
+		})
+	}
+
+	return links, nil
+}
+
+// OpenDocument opens a document for a signed link, the caller closes the returned reader
+func (s *Service) OpenDocument(ctx context.Context, dID uint, expires int64, signature string) (model.Document, io.ReadCloser, error) {
+
+	if !s.signer.Verify(dID, expires, signature, time.Now()) {
+		return model.Document{}, nil, ErrInvalidLink
+	}
+
+	document, err := s.docRepo.GetDocumentByID(dID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return model.Document{}, nil, ErrDocumentNotFound
+	}
+	if err != nil {
+		return model.Document{}, nil, err
+	}
+
+	file, err := s.storage.Open(ctx, document.StorageKey)
+	if err != nil {
+		log.Error().Err(err).Uint("document id", dID).Msg("error in opening stored document")
+		return model.Document{}, nil, errors.New("could not open the document")
+	}
+
+	return document, file, nil
+}
+
+func (s *Service) deleteStoredDocument(ctx context.Context, key string) {
+	err := s.storage.Delete(ctx, key)
+	if err != nil {
+		log.Error().Err(err).Str("key", key).Msg("error in deleting stored document")
+	}
+}
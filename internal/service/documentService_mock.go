@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: documentService.go
+//
+// Generated by this command:
+//
+//	mockgen -source=documentService.go -destination=documentService_mock.go -package=service
+//
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	io "io"
+	model "job-portal-api/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDocumentService is a mock of DocumentService interface.
+type MockDocumentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentServiceMockRecorder
+}
+
+// MockDocumentServiceMockRecorder is the mock recorder for MockDocumentService.
+type MockDocumentServiceMockRecorder struct {
+	mock *MockDocumentService
+}
+
+// NewMockDocumentService creates a new mock instance.
+func NewMockDocumentService(ctrl *gomock.Controller) *MockDocumentService {
+	mock := &MockDocumentService{ctrl: ctrl}
+	mock.recorder = &MockDocumentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocumentService) EXPECT() *MockDocumentServiceMockRecorder {
+	return m.recorder
+}
+
+// OpenDocument mocks base method.
+func (m *MockDocumentService) OpenDocument(ctx context.Context, dID uint, expires int64, signature string) (model.Document, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenDocument", ctx, dID, expires, signature)
+	ret0, _ := ret[0].(model.Document)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenDocument indicates an expected call of OpenDocument.
+func (mr *MockDocumentServiceMockRecorder) OpenDocument(ctx, dID, expires, signature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenDocument", reflect.TypeOf((*MockDocumentService)(nil).OpenDocument), ctx, dID, expires, signature)
+}
+
+// UploadDocument mocks base method.
+func (m *MockDocumentService) UploadDocument(ctx context.Context, uID uint, upload model.NewDocument, file io.Reader) (model.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadDocument", ctx, uID, upload, file)
+	ret0, _ := ret[0].(model.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadDocument indicates an expected call of UploadDocument.
+func (mr *MockDocumentServiceMockRecorder) UploadDocument(ctx, uID, upload, file any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadDocument", reflect.TypeOf((*MockDocumentService)(nil).UploadDocument), ctx, uID, upload, file)
+}
+
+// ViewApplicationDocuments mocks base method.
+func (m *MockDocumentService) ViewApplicationDocuments(aID, uID uint) ([]model.DocumentLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewApplicationDocuments", aID, uID)
+	ret0, _ := ret[0].([]model.DocumentLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewApplicationDocuments indicates an expected call of ViewApplicationDocuments.
+func (mr *MockDocumentServiceMockRecorder) ViewApplicationDocuments(aID, uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewApplicationDocuments", reflect.TypeOf((*MockDocumentService)(nil).ViewApplicationDocuments), aID, uID)
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"job-portal-api/internal/model"
+	"job-portal-api/internal/repository"
+	"job-portal-api/internal/storage"
+	"job-portal-api/internal/textextract"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	gomock "go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestService_UploadDocument(t *testing.T) {
+	appID := uint(3)
+	tests := []struct {
+		name      string
+		upload    model.NewDocument
+		content   string
+		wantErr   error
+		mockApp   func() (model.Application, error)
+		wantSaved bool
+	}{
+		{
+			name:    "declared size too large",
+			upload:  model.NewDocument{Kind: model.DocumentResume, FileName: "cv.pdf", Size: 100},
+			content: "%PDF-1.7",
+			wantErr: ErrDocumentTooLarge,
+		},
+		{
+			name:    "unsupported extension",
+			upload:  model.NewDocument{Kind: model.DocumentResume, FileName: "cv.txt", Size: 8},
+			content: "%PDF-1.7",
+			wantErr: ErrInvalidDocument,
+		},
+		{
+			name:    "renamed file",
+			upload:  model.NewDocument{Kind: model.DocumentResume, FileName: "cv.docx", Size: 8},
+			content: "%PDF-1.7",
+			wantErr: ErrInvalidDocument,
+		},
+		{
+			name:    "application of another user",
+			upload:  model.NewDocument{Kind: model.DocumentCoverLetter, FileName: "letter.pdf", Size: 8, ApplicationID: &appID},
+			content: "%PDF-1.7",
+			wantErr: ErrApplicationNotFound,
+			mockApp: func() (model.Application, error) {
+				return model.Application{Model: gorm.Model{ID: 3}, UserID: 2}, nil
+			},
+		},
+		{
+			name:      "file larger than declared",
+			upload:    model.NewDocument{Kind: model.DocumentResume, FileName: "cv.pdf", Size: 8},
+			content:   "%PDF-1.7" + strings.Repeat("a", 20),
+			wantErr:   ErrDocumentTooLarge,
+			wantSaved: true,
+		},
+		{
+			name:      "success",
+			upload:    model.NewDocument{Kind: model.DocumentResume, FileName: "CV.PDF", Size: 8},
+			content:   "%PDF-1.7",
+			wantSaved: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			md := repository.NewMockDocumentRepository(mc)
+			ma := repository.NewMockApplicationRepository(mc)
+			ms := storage.NewMockStorage(mc)
+			mcr := repository.NewMockCandidateRepository(mc)
+			mt := repository.NewMockTaxonomyRepository(mc)
+			me := textextract.NewMockExtractor(mc)
+			signer, _ := storage.NewSigner("secret", time.Minute)
+			s, _ := NewDocumentService(md, ma, repository.NewMockJobRepository(mc), repository.NewMockMemberRepository(mc), mcr, mt, ms, signer, me, 16)
+			if tt.mockApp != nil {
+				ma.EXPECT().GetApplicationByID(appID).Return(tt.mockApp())
+			}
+			var saved bytes.Buffer
+			if tt.wantSaved {
+				ms.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, key string, r io.Reader) (int64, error) {
+					if !strings.HasPrefix(key, "1/") || !strings.HasSuffix(key, ".pdf") {
+						t.Errorf("Service.UploadDocument() stored under %q", key)
+					}
+					return io.Copy(&saved, r)
+				})
+			}
+			if tt.wantSaved && tt.wantErr != nil {
+				ms.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+			}
+			if tt.wantSaved && tt.wantErr == nil {
+				md.EXPECT().CreateDocument(gomock.Any()).DoAndReturn(func(document model.Document) (model.Document, error) {
+					if document.ContentType != model.ContentTypePDF || document.Size != 8 || document.UserID != 1 {
+						t.Errorf("Service.UploadDocument() created %v", document)
+					}
+					document.ID = 7
+					return document, nil
+				})
+				ms.EXPECT().Open(gomock.Any(), gomock.Any()).Return(io.NopCloser(strings.NewReader(tt.content)), nil)
+				me.EXPECT().Extract(model.ContentTypePDF, gomock.Any()).Return("Go and JavaScript developer, B.Tech", nil)
+				mt.EXPECT().GetTaxonomies(model.TaxonomyTechnologyStack, false).Return([]model.Taxonomy{{ID: 1, Name: "Go"}, {ID: 2, Name: "Java"}, {ID: 3, Name: "JavaScript"}}, nil)
+				mt.EXPECT().GetTaxonomies(model.TaxonomyQualification, false).Return([]model.Taxonomy{{ID: 4, Name: "b.tech"}}, nil)
+				skills := model.DetectedSkills{TechnologyStack: []uint{1, 3}, Qualifications: []uint{4}}
+				md.EXPECT().SetDocumentSkills(uint(7), skills).Return(nil)
+				mcr.EXPECT().AddProfileSkills(uint(1), skills).Return(repository.ErrNotFound)
+			}
+			got, err := s.UploadDocument(context.Background(), 1, tt.upload, strings.NewReader(tt.content))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.UploadDocument() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && saved.String() != tt.content {
+				t.Errorf("Service.UploadDocument() saved %q, want %q", saved.String(), tt.content)
+			}
+			if tt.wantErr == nil && len(got.Skills.TechnologyStack) != 2 {
+				t.Errorf("Service.UploadDocument() detected %v", got.Skills)
+			}
+		})
+	}
+}
+
+func TestService_OpenDocument(t *testing.T) {
+	signer, _ := storage.NewSigner("secret", time.Minute)
+	expires, signature := signer.Sign(5, time.Now())
+	tests := []struct {
+		name      string
+		signature string
+		wantErr   error
+		mockDoc   func() (model.Document, error)
+	}{
+		{
+			name:      "invalid signature",
+			signature: "abcd",
+			wantErr:   ErrInvalidLink,
+		},
+		{
+			name:      "document deleted",
+			signature: signature,
+			wantErr:   ErrDocumentNotFound,
+			mockDoc: func() (model.Document, error) {
+				return model.Document{}, repository.ErrNotFound
+			},
+		},
+		{
+			name:      "success",
+			signature: signature,
+			mockDoc: func() (model.Document, error) {
+				return model.Document{Model: gorm.Model{ID: 5}, StorageKey: "1/cv.pdf"}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			md := repository.NewMockDocumentRepository(mc)
+			ms := storage.NewMockStorage(mc)
+			s, _ := NewDocumentService(md, repository.NewMockApplicationRepository(mc), repository.NewMockJobRepository(mc), repository.NewMockMemberRepository(mc),
+				repository.NewMockCandidateRepository(mc), repository.NewMockTaxonomyRepository(mc), ms, signer, textextract.NewMockExtractor(mc), 16)
+			if tt.mockDoc != nil {
+				md.EXPECT().GetDocumentByID(uint(5)).Return(tt.mockDoc())
+			}
+			if tt.wantErr == nil {
+				ms.EXPECT().Open(gomock.Any(), "1/cv.pdf").Return(io.NopCloser(strings.NewReader("%PDF-1.7")), nil)
+			}
+			_, file, err := s.OpenDocument(context.Background(), 5, expires.Unix(), tt.signature)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.OpenDocument() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if file != nil {
+				file.Close()
+			}
+		})
+	}
+}
+
+func Test_findTaxonomies(t *testing.T) {
+	taxonomies := []model.Taxonomy{{ID: 1, Name: "Go"}, {ID: 2, Name: "Java"}, {ID: 3, Name: "C++"}, {ID: 4, Name: "node.js"}}
+	tests := []struct {
+		name string
+		text string
+		want []uint
+	}{
+		{
+			name: "whole words in any case",
+			text: "Worked with GO, java and Node.js",
+			want: []uint{1, 2, 4},
+		},
+		{
+			name: "names inside other words",
+			text: "javascript, golang, good",
+		},
+		{
+			name: "names with symbols",
+			text: "c++ at the start",
+			want: []uint{3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findTaxonomies(tt.text, taxonomies); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findTaxonomies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
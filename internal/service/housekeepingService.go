@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"job-portal-api/internal/cache"
+	"job-portal-api/internal/repository"
+	"job-portal-api/internal/storage"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=housekeepingService.go -destination=housekeepingService_mock.go -package=service
+type HousekeepingService interface {
+	ExpireJobs(ctx context.Context) (int, error)
+	PurgeDeleted(ctx context.Context, retention time.Duration) (map[string]int64, error)
+	RefreshJobStats(ctx context.Context) error
+}
+
+func NewHousekeepingService(housekeepingRepo repository.HousekeepingRepository, rdb cache.Caching, store storage.Storage) (HousekeepingService, error) {
+	if housekeepingRepo == nil {
+		log.Info().Msg("housekeeping repo cannot be nil")
+		return nil, errors.New("housekeeping repo cannot be nil")
+	}
+	if store == nil {
+		log.Info().Msg("storage cannot be nil")
+		return nil, errors.New("storage cannot be nil")
+	}
+	return &Service{
+		housekeepingRepo: housekeepingRepo,
+		rdb:              rdb,
+		storage:          store,
+	}, nil
+}
+
+// ExpireJobs marks the jobs past their expiry date as expired and returns how many were expired
+func (s *Service) ExpireJobs(ctx context.Context) (int, error) {
+
+	ids, err := s.housekeepingRepo.ExpireJobs(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	for _, v := range ids {
+		err = s.rdb.DeleteTheCacheData(ctx, v)
+		if err != nil {
+			log.Error().Err(err).Uint("job id", v).Msg("error in removing job from cache")
+		}
+	}
+
+	return len(ids), nil
+}
+
+// PurgeDeleted removes the rows deleted longer than the retention ago and the stored files of their documents
+func (s *Service) PurgeDeleted(ctx context.Context, retention time.Duration) (map[string]int64, error) {
+	if retention <= 0 {
+		return nil, errors.New("retention must be positive")
+	}
+
+	purged, storageKeys, err := s.housekeepingRepo.PurgeDeleted(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range storageKeys {
+		s.deleteStoredDocument(ctx, key)
+	}
+
+	return purged, nil
+}
+
+func (s *Service) RefreshJobStats(ctx context.Context) error {
+	return s.housekeepingRepo.RefreshJobStats(ctx, time.Now())
+}
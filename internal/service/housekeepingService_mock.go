@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: housekeepingService.go
+//
+// Generated by this command:
+//
+//	mockgen -source=housekeepingService.go -destination=housekeepingService_mock.go -package=service
+//
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHousekeepingService is a mock of HousekeepingService interface.
+type MockHousekeepingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHousekeepingServiceMockRecorder
+}
+
+// MockHousekeepingServiceMockRecorder is the mock recorder for MockHousekeepingService.
+type MockHousekeepingServiceMockRecorder struct {
+	mock *MockHousekeepingService
+}
+
+// NewMockHousekeepingService creates a new mock instance.
+func NewMockHousekeepingService(ctrl *gomock.Controller) *MockHousekeepingService {
+	mock := &MockHousekeepingService{ctrl: ctrl}
+	mock.recorder = &MockHousekeepingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHousekeepingService) EXPECT() *MockHousekeepingServiceMockRecorder {
+	return m.recorder
+}
+
+// ExpireJobs mocks base method.
+func (m *MockHousekeepingService) ExpireJobs(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireJobs", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireJobs indicates an expected call of ExpireJobs.
+func (mr *MockHousekeepingServiceMockRecorder) ExpireJobs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireJobs", reflect.TypeOf((*MockHousekeepingService)(nil).ExpireJobs), ctx)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockHousekeepingService) PurgeDeleted(ctx context.Context, retention time.Duration) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, retention)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockHousekeepingServiceMockRecorder) PurgeDeleted(ctx, retention any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockHousekeepingService)(nil).PurgeDeleted), ctx, retention)
+}
+
+// RefreshJobStats mocks base method.
+func (m *MockHousekeepingService) RefreshJobStats(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshJobStats", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshJobStats indicates an expected call of RefreshJobStats.
+func (mr *MockHousekeepingServiceMockRecorder) RefreshJobStats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshJobStats", reflect.TypeOf((*MockHousekeepingService)(nil).RefreshJobStats), ctx)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"job-portal-api/internal/cache"
+	"job-portal-api/internal/repository"
+	"job-portal-api/internal/storage"
+	"reflect"
+	"testing"
+	"time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestService_ExpireJobs(t *testing.T) {
+	tests := []struct {
+		name       string
+		want       int
+		wantErr    bool
+		wantForget int
+		mockExpire func() ([]uint, error)
+	}{
+		{
+			name:    "error in expiring jobs",
+			want:    0,
+			wantErr: true,
+			mockExpire: func() ([]uint, error) {
+				return nil, errors.New("error")
+			},
+		},
+		{
+			name:       "expired jobs are removed from cache",
+			want:       2,
+			wantErr:    false,
+			wantForget: 2,
+			mockExpire: func() ([]uint, error) {
+				return []uint{1, 2}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mh := repository.NewMockHousekeepingRepository(mc)
+			mca := cache.NewMockCaching(mc)
+			s, _ := NewHousekeepingService(mh, mca, storage.NewMockStorage(mc))
+			mh.EXPECT().ExpireJobs(gomock.Any(), gomock.Any()).Return(tt.mockExpire())
+			mca.EXPECT().DeleteTheCacheData(gomock.Any(), gomock.Any()).Return(nil).Times(tt.wantForget)
+			got, err := s.ExpireJobs(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.ExpireJobs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Service.ExpireJobs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_PurgeDeleted(t *testing.T) {
+	tests := []struct {
+		name       string
+		retention  time.Duration
+		want       map[string]int64
+		wantErr    bool
+		wantDelete []string
+		mockPurge  func() (map[string]int64, []string, error)
+	}{
+		{
+			name:      "retention must be positive",
+			retention: 0,
+			want:      nil,
+			wantErr:   true,
+		},
+		{
+			name:      "error in purging",
+			retention: time.Hour,
+			want:      nil,
+			wantErr:   true,
+			mockPurge: func() (map[string]int64, []string, error) {
+				return nil, nil, errors.New("error")
+			},
+		},
+		{
+			name:      "success",
+			retention: time.Hour,
+			want:      map[string]int64{"jobs": 2, "companies": 1},
+			wantErr:   false,
+			mockPurge: func() (map[string]int64, []string, error) {
+				return map[string]int64{"jobs": 2, "companies": 1}, nil, nil
+			},
+		},
+		{
+			name:       "stored files of purged documents are deleted",
+			retention:  time.Hour,
+			want:       map[string]int64{"applications": 1, "documents": 2},
+			wantErr:    false,
+			wantDelete: []string{"1/a.pdf", "1/b.pdf"},
+			mockPurge: func() (map[string]int64, []string, error) {
+				return map[string]int64{"applications": 1, "documents": 2}, []string{"1/a.pdf", "1/b.pdf"}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mh := repository.NewMockHousekeepingRepository(mc)
+			ms := storage.NewMockStorage(mc)
+			s, _ := NewHousekeepingService(mh, cache.NewMockCaching(mc), ms)
+			if tt.mockPurge != nil {
+				mh.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, before time.Time) (map[string]int64, []string, error) {
+					if time.Since(before) < tt.retention {
+						t.Errorf("Service.PurgeDeleted() purges rows deleted after %v", before)
+					}
+					return tt.mockPurge()
+				})
+			}
+			for _, key := range tt.wantDelete {
+				ms.EXPECT().Delete(gomock.Any(), key).Return(nil)
+			}
+			got, err := s.PurgeDeleted(context.Background(), tt.retention)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.PurgeDeleted() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.PurgeDeleted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,10 +3,12 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"job-portal-api/internal/cache"
 	"job-portal-api/internal/model"
 	"job-portal-api/internal/repository"
 	"sync"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
@@ -14,24 +16,109 @@ import (
 
 //go:generate mockgen -source=jobService.go -destination=jobService_mock.go -package=service
 type JobService interface {
-	CreateJobByCompanyId(jobdata model.NewJobs, cID uint) (model.Response, error)
-	ViewJobByCompanyID(cID uint) ([]model.Job, error)
-	ViewJobByJobID(jID uint) (model.Job, error)
-	ViewAllJobs() ([]model.Job, error)
-	ProcessApplication(applications []model.NewUserApplication) []model.NewUserApplication
+	CreateJobByCompanyId(jobdata model.NewJobs, cID uint, uID uint) (model.Response, error)
+	ViewJobByCompanyID(cID uint, uID uint) ([]model.Job, error)
+	ViewJobByJobID(jID uint, uID uint) (model.Job, error)
+	ViewAllJobs(filter model.JobFilter) (model.JobList, error)
+	SearchJobs(search model.JobSearch) (model.JobSearchList, error)
+	ProcessApplication(uID uint, applications []model.NewUserApplication) []model.ProcessedApplication
+	ReplaceJob(jID uint, uID uint, jobDetails model.NewJobs) (model.Job, error)
+	UpdateJob(jID uint, uID uint, update model.UpdateJob) (model.Job, error)
+	SetJobClosed(jID uint, uID uint, closed bool) (model.Job, error)
+	SetJobStatus(jID uint, uID uint, status string) (model.Job, error)
+	DeleteJob(jID uint, uID uint) error
 }
 
-func NewJobService(jobService repository.JobRepository, rdb cache.Caching) (JobService, error) {
+func NewJobService(jobService repository.JobRepository, memberRepo repository.MemberRepository, appRepo repository.ApplicationRepository,
+	taxonomyRepo repository.TaxonomyRepository, comapnyRepo repository.ComapnyRepo, rdb cache.Caching) (JobService, error) {
 	if jobService == nil {
 		log.Info().Msg("jobservice cannot be nil")
 	}
 	return &Service{
-		jobRepo: jobService,
-		rdb:     rdb,
+		jobRepo:      jobService,
+		memberRepo:   memberRepo,
+		appRepo:      appRepo,
+		taxonomyRepo: taxonomyRepo,
+		comapnayRepo: comapnyRepo,
+		rdb:          rdb,
 	}, nil
 }
 
-func (s *Service) CreateJobByCompanyId(jobDetails model.NewJobs, cID uint) (model.Response, error) {
+// checkJobReferences makes sure the company and every taxonomy id given for a job can be used,
+// a zero company id and nil lists are not checked
+func (s *Service) checkJobReferences(cID uint, refs model.UpdateJob) error {
+	var invalid model.InvalidReferences
+	found := false
+
+	if cID != 0 {
+		_, err := s.comapnayRepo.GetCompanyByID(uint64(cID))
+		if errors.Is(err, repository.ErrNotFound) {
+			invalid.CompanyID = cID
+			found = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	lists := []struct {
+		kind    string
+		ids     *[]uint
+		invalid *[]uint
+	}{
+		{model.TaxonomyLocation, refs.Location, &invalid.Location},
+		{model.TaxonomyTechnologyStack, refs.TechnologyStack, &invalid.TechnologyStack},
+		{model.TaxonomyQualification, refs.Qualifications, &invalid.Qualifications},
+		{model.TaxonomyShift, refs.Shift, &invalid.Shift},
+		{model.TaxonomyJobType, refs.Jobtype, &invalid.Jobtype},
+	}
+	for _, v := range lists {
+		if v.ids == nil || len(*v.ids) == 0 {
+			continue
+		}
+		ids, err := s.taxonomyRepo.GetUnusableTaxonomyIDs(v.kind, *v.ids)
+		if err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			*v.invalid = ids
+			found = true
+		}
+	}
+
+	if found {
+		return &InvalidReferencesError{References: invalid}
+	}
+	return nil
+}
+
+func (s *Service) CreateJobByCompanyId(jobDetails model.NewJobs, cID uint, uID uint) (model.Response, error) {
+
+	//references are checked first so a company that does not exist is reported as such and not
+	//as a missing membership
+	err := s.checkJobReferences(cID, model.UpdateJob{
+		Location:        &jobDetails.Location,
+		TechnologyStack: &jobDetails.TechnologyStack,
+		Qualifications:  &jobDetails.Qualifications,
+		Shift:           &jobDetails.Shift,
+		Jobtype:         &jobDetails.Jobtype,
+	})
+	if err != nil {
+		return model.Response{}, err
+	}
+
+	_, err = s.checkCompanyMember(cID, uID, model.MemberRoleOwner, model.MemberRoleRecruiter)
+	if err != nil {
+		return model.Response{}, err
+	}
+
+	if jobDetails.ExpiresAt != nil && !jobDetails.ExpiresAt.After(time.Now()) {
+		return model.Response{}, ErrInvalidExpiry
+	}
+
+	status := model.JobStatusPublished
+	if jobDetails.Draft {
+		status = model.JobStatusDraft
+	}
 
 	jobData := model.Job{
 		Cid:             cID,
@@ -41,6 +128,18 @@ func (s *Service) CreateJobByCompanyId(jobDetails model.NewJobs, cID uint) (mode
 		Description:     jobDetails.Description,
 		MinExperience:   jobDetails.MinExperience,
 		MaxExperience:   jobDetails.MaxExperience,
+		Criteria:        defaultMatchCriteria(),
+		MinSalary:       jobDetails.MinSalary,
+		MaxSalary:       jobDetails.MaxSalary,
+		Currency:        jobDetails.Currency,
+		PayPeriod:       jobDetails.PayPeriod,
+		WorkMode:        jobDetails.WorkMode,
+		Status:          status,
+		ExpiresAt:       jobDetails.ExpiresAt,
+	}
+
+	if jobDetails.Criteria != nil {
+		jobData.Criteria = *jobDetails.Criteria
 	}
 
 	for _, v := range jobDetails.Jobtype {
@@ -97,9 +196,13 @@ func (s *Service) CreateJobByCompanyId(jobDetails model.NewJobs, cID uint) (mode
 
 }
 
-func (s *Service) ViewJobByCompanyID(cID uint) ([]model.Job, error) {
+// ViewJobByCompanyID lists every job of the company to its members and only the listed ones to everyone else
+func (s *Service) ViewJobByCompanyID(cID uint, uID uint) ([]model.Job, error) {
 
-	jobData, err := s.jobRepo.GetJobByCompanyID(cID)
+	_, err := s.checkCompanyMember(cID, uID, model.MemberRoleOwner, model.MemberRoleRecruiter, model.MemberRoleViewer)
+	listedOnly := err != nil
+
+	jobData, err := s.jobRepo.GetJobByCompanyID(cID, listedOnly)
 
 	if err != nil {
 		return nil, err
@@ -108,161 +211,615 @@ func (s *Service) ViewJobByCompanyID(cID uint) ([]model.Job, error) {
 	return jobData, nil
 }
 
-func (s *Service) ViewJobByJobID(jID uint) (model.Job, error) {
+// ViewJobByJobID returns the job, drafts and archived jobs are only shown to the members of its company
+func (s *Service) ViewJobByJobID(jID uint, uID uint) (model.Job, error) {
 
 	jobData, err := s.jobRepo.GetJobByJobID(jID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return model.Job{}, ErrJobNotFound
+	}
 	if err != nil {
 		return model.Job{}, err
 	}
+
+	if jobData.Status == model.JobStatusDraft || jobData.Status == model.JobStatusArchived {
+		_, err = s.checkCompanyMember(jobData.Cid, uID, model.MemberRoleOwner, model.MemberRoleRecruiter, model.MemberRoleViewer)
+		if err != nil {
+			return model.Job{}, ErrJobNotFound
+		}
+	}
+	jobData.Status = jobStatus(jobData, time.Now())
+
 	return jobData, nil
 }
 
-func (s *Service) ViewAllJobs() ([]model.Job, error) {
-	jobData, err := s.jobRepo.GetAllJobs()
+// defaultJobPageSize is used when a job listing does not ask for a page size
+const defaultJobPageSize = 20
+
+func (s *Service) ViewAllJobs(filter model.JobFilter) (model.JobList, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = defaultJobPageSize
+	}
+
+	jobData, total, err := s.jobRepo.GetAllJobs(filter)
 	if err != nil {
-		return nil, err
+		return model.JobList{}, err
+	}
+
+	return model.JobList{
+		Jobs:     jobData,
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	}, nil
+}
+
+func (s *Service) SearchJobs(search model.JobSearch) (model.JobSearchList, error) {
+	if search.Page < 1 {
+		search.Page = 1
+	}
+	if search.PageSize < 1 {
+		search.PageSize = defaultJobPageSize
+	}
+
+	results, total, err := s.jobRepo.SearchJobs(search)
+	if err != nil {
+		return model.JobSearchList{}, err
+	}
+
+	return model.JobSearchList{
+		Results:  results,
+		Total:    total,
+		Page:     search.Page,
+		PageSize: search.PageSize,
+	}, nil
+}
+
+// managedJob returns the job when its company is not deleted and the user is allowed to manage its jobs
+func (s *Service) managedJob(jID uint, uID uint) (model.Job, error) {
+	jobData, err := s.jobRepo.GetJobByJobID(jID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return model.Job{}, ErrJobNotFound
+	}
+	if err != nil {
+		return model.Job{}, err
+	}
+
+	//the jobs of a deleted company stay hidden and can not be managed anymore
+	_, err = s.comapnayRepo.GetCompanyByID(uint64(jobData.Cid))
+	if errors.Is(err, repository.ErrNotFound) {
+		return model.Job{}, ErrJobNotFound
+	}
+	if err != nil {
+		return model.Job{}, err
+	}
+
+	_, err = s.checkCompanyMember(jobData.Cid, uID, model.MemberRoleOwner, model.MemberRoleRecruiter)
+	if err != nil {
+		return model.Job{}, err
 	}
 
 	return jobData, nil
 }
 
-func (s *Service) ProcessApplication(applications []model.NewUserApplication) []model.NewUserApplication {
+// forgetJob drops the cached copy of a changed job so applications are matched against the new data
+func (s *Service) forgetJob(jID uint) {
+	err := s.rdb.DeleteTheCacheData(context.Background(), jID)
+	if err != nil {
+		log.Error().Err(err).Uint("job id", jID).Msg("error in removing job from cache")
+	}
+}
+
+// ReplaceJob overwrites every field of the job, a missing match criteria resets it to the default one
+func (s *Service) ReplaceJob(jID uint, uID uint, jobDetails model.NewJobs) (model.Job, error) {
+
+	criteria := defaultMatchCriteria()
+	if jobDetails.Criteria != nil {
+		criteria = *jobDetails.Criteria
+	}
+
+	return s.UpdateJob(jID, uID, model.UpdateJob{
+		Jobname:         &jobDetails.Jobname,
+		MinNoticePeriod: &jobDetails.MinNoticePeriod,
+		MaxNoticePeriod: &jobDetails.MaxNoticePeriod,
+		Location:        &jobDetails.Location,
+		TechnologyStack: &jobDetails.TechnologyStack,
+		Description:     &jobDetails.Description,
+		MinExperience:   &jobDetails.MinExperience,
+		MaxExperience:   &jobDetails.MaxExperience,
+		Qualifications:  &jobDetails.Qualifications,
+		Shift:           &jobDetails.Shift,
+		Jobtype:         &jobDetails.Jobtype,
+		Criteria:        &criteria,
+		MinSalary:       &jobDetails.MinSalary,
+		MaxSalary:       &jobDetails.MaxSalary,
+		Currency:        &jobDetails.Currency,
+		PayPeriod:       &jobDetails.PayPeriod,
+		WorkMode:        &jobDetails.WorkMode,
+	})
+}
+
+func (s *Service) UpdateJob(jID uint, uID uint, update model.UpdateJob) (model.Job, error) {
+
+	jobData, err := s.managedJob(jID, uID)
+	if err != nil {
+		return model.Job{}, err
+	}
+
+	err = s.checkJobReferences(0, update)
+	if err != nil {
+		return model.Job{}, err
+	}
+
+	if update.ExpiresAt != nil {
+		if !update.ExpiresAt.After(time.Now()) {
+			return model.Job{}, ErrInvalidExpiry
+		}
+		jobData.ExpiresAt = update.ExpiresAt
+	}
+	if update.Jobname != nil {
+		jobData.Jobname = *update.Jobname
+	}
+	if update.MinNoticePeriod != nil {
+		jobData.MinNoticePeriod = *update.MinNoticePeriod
+	}
+	if update.MaxNoticePeriod != nil {
+		jobData.MaxNoticePeriod = *update.MaxNoticePeriod
+	}
+	if update.Description != nil {
+		jobData.Description = *update.Description
+	}
+	if update.MinExperience != nil {
+		jobData.MinExperience = *update.MinExperience
+	}
+	if update.MaxExperience != nil {
+		jobData.MaxExperience = *update.MaxExperience
+	}
+	if update.Criteria != nil {
+		jobData.Criteria = *update.Criteria
+	}
+	if update.MinSalary != nil {
+		jobData.MinSalary = *update.MinSalary
+	}
+	if update.MaxSalary != nil {
+		jobData.MaxSalary = *update.MaxSalary
+	}
+	if update.Currency != nil {
+		jobData.Currency = *update.Currency
+	}
+	if update.PayPeriod != nil {
+		jobData.PayPeriod = *update.PayPeriod
+	}
+	if update.WorkMode != nil {
+		jobData.WorkMode = *update.WorkMode
+	}
+	if jobData.MaxSalary != 0 && jobData.MaxSalary < jobData.MinSalary {
+		return model.Job{}, ErrInvalidSalary
+	}
+
+	if update.Location != nil {
+		jobData.Location = []model.Location{}
+		for _, v := range *update.Location {
+			jobData.Location = append(jobData.Location, model.Location{Model: gorm.Model{ID: v}})
+		}
+	}
+	if update.TechnologyStack != nil {
+		jobData.TechnologyStack = []model.TechnologyStack{}
+		for _, v := range *update.TechnologyStack {
+			jobData.TechnologyStack = append(jobData.TechnologyStack, model.TechnologyStack{Model: gorm.Model{ID: v}})
+		}
+	}
+	if update.Qualifications != nil {
+		jobData.Qualifications = []model.Qualification{}
+		for _, v := range *update.Qualifications {
+			jobData.Qualifications = append(jobData.Qualifications, model.Qualification{Model: gorm.Model{ID: v}})
+		}
+	}
+	if update.Shift != nil {
+		jobData.Shift = []model.Shift{}
+		for _, v := range *update.Shift {
+			jobData.Shift = append(jobData.Shift, model.Shift{Model: gorm.Model{ID: v}})
+		}
+	}
+	if update.Jobtype != nil {
+		jobData.Jobtype = []model.JobType{}
+		for _, v := range *update.Jobtype {
+			jobData.Jobtype = append(jobData.Jobtype, model.JobType{Model: gorm.Model{ID: v}})
+		}
+	}
+
+	jobData, err = s.jobRepo.UpdateJob(jobData)
+	if err != nil {
+		return model.Job{}, err
+	}
+	s.forgetJob(jID)
+
+	return jobData, nil
+}
+
+// SetJobClosed closes the job for new applications or reopens it
+func (s *Service) SetJobClosed(jID uint, uID uint, closed bool) (model.Job, error) {
+
+	jobData, err := s.managedJob(jID, uID)
+	if err != nil {
+		return model.Job{}, err
+	}
+
+	err = s.jobRepo.SetJobClosed(jID, closed)
+	if err != nil {
+		return model.Job{}, err
+	}
+	s.forgetJob(jID)
+
+	jobData.Closed = closed
+	return jobData, nil
+}
+
+// jobTransitions lists the statuses a job can move to from each status, archived jobs stay archived
+var jobTransitions = map[string][]string{
+	model.JobStatusDraft:     {model.JobStatusPublished, model.JobStatusArchived},
+	model.JobStatusPublished: {model.JobStatusArchived},
+	model.JobStatusExpired:   {model.JobStatusPublished, model.JobStatusArchived},
+}
+
+// jobStatus returns the status of the job at the given time, a published job past its expiry date
+// is expired even before it is marked so
+func jobStatus(jobData model.Job, now time.Time) string {
+	if jobData.Status == model.JobStatusPublished && !jobData.Listed(now) {
+		return model.JobStatusExpired
+	}
+	return jobData.Status
+}
+
+// SetJobStatus publishes or archives the job, an expired job can only be published again
+// once its expiry date has been moved to the future
+func (s *Service) SetJobStatus(jID uint, uID uint, status string) (model.Job, error) {
+
+	jobData, err := s.managedJob(jID, uID)
+	if err != nil {
+		return model.Job{}, err
+	}
+
+	now := time.Now()
+
+	allowed := false
+	for _, v := range jobTransitions[jobStatus(jobData, now)] {
+		if v == status {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return model.Job{}, ErrInvalidJobStatus
+	}
+
+	if status == model.JobStatusPublished && jobData.ExpiresAt != nil && !jobData.ExpiresAt.After(now) {
+		return model.Job{}, ErrInvalidExpiry
+	}
+
+	err = s.jobRepo.SetJobStatus(jID, status)
+	if errors.Is(err, repository.ErrNotFound) {
+		return model.Job{}, ErrJobNotFound
+	}
+	if err != nil {
+		return model.Job{}, err
+	}
+	s.forgetJob(jID)
+
+	jobData.Status = status
+	return jobData, nil
+}
+
+func (s *Service) DeleteJob(jID uint, uID uint) error {
+
+	_, err := s.managedJob(jID, uID)
+	if err != nil {
+		return err
+	}
+
+	err = s.jobRepo.DeleteJob(jID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrJobNotFound
+	}
+	if err != nil {
+		return err
+	}
+	s.forgetJob(jID)
+
+	return nil
+}
+
+// ProcessApplication matches and stores every application of the batch, the result holds one entry per
+// application in the order they were sent so callers can reconcile it with their input
+func (s *Service) ProcessApplication(uID uint, applications []model.NewUserApplication) []model.ProcessedApplication {
 	ctx := context.Background()
 	wg := new(sync.WaitGroup)
-	ch := make(chan model.NewUserApplication)
-	var finalData []model.NewUserApplication
+	finalData := make([]model.ProcessedApplication, len(applications))
+
+	for i, v := range applications {
+		//applications processed by a recruiter are not tied to a user account, only a candidate
+		//applying for themselves is
+		v.UserID = 0
 
-	for _, v := range applications {
 		wg.Add(1)
-		go func(application model.NewUserApplication) {
+		go func(index int, application model.NewUserApplication) {
 			defer wg.Done()
 
-			var jobData model.Job
-
-			val, err := s.rdb.GetTheCacheData(ctx, application.Jid)
-
-			if err != nil {
-				jobDataFromDB, err := s.jobRepo.GetJobByJobID(application.Jid)
-				if err != nil {
-					log.Error().Err(err).Msg("invalid application job id does not exists")
-					return
-				}
-				err = s.rdb.AddToTheCache(ctx, application.Jid, jobDataFromDB)
-				if err != nil {
-					return
-				}
-				jobData = jobDataFromDB
-
-			} else {
-				err = json.Unmarshal([]byte(val), &jobData)
-				if err != nil {
-					log.Error().Err(err).Msg("error in un marshaling")
-					return
-				}
-			}
-			check := CompareData(application, jobData)
+			//each goroutine only writes its own index so no locking is needed
+			finalData[index] = s.processApplication(ctx, index, application, uID)
+		}(i, v)
+	}
 
-			if check {
-				ch <- application
-			}
+	wg.Wait()
 
-		}(v)
+	return finalData
+}
+
+// processApplication matches one application against its job and stores it along with the decision,
+// when a recruiter id is given the job has to belong to a company the recruiter manages jobs for
+func (s *Service) processApplication(ctx context.Context, index int, application model.NewUserApplication, recruiterID uint) model.ProcessedApplication {
+	result := model.ProcessedApplication{
+		Index:       index,
+		Application: application,
 	}
 
-	go func() {
-		wg.Wait()
-		close(ch)
-	}()
+	var jobData model.Job
+
+	val, err := s.rdb.GetTheCacheData(ctx, application.Jid)
 
-	for v := range ch {
-		finalData = append(finalData, v)
+	if err != nil {
+		jobDataFromDB, err := s.jobRepo.GetJobByJobID(application.Jid)
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Error().Err(err).Uint("job id", application.Jid).Msg("invalid application job id does not exists")
+			result.Outcome = model.OutcomeInvalidJob
+			result.Error = "job does not exist"
+			return result
+		}
+		if err != nil {
+			log.Error().Err(err).Uint("job id", application.Jid).Msg("error in fetching the job")
+			result.Outcome = model.OutcomeInternalError
+			result.Error = "could not fetch the job"
+			return result
+		}
+		//the cache is only an optimisation, the application is still processed when it fails
+		err = s.rdb.AddToTheCache(ctx, application.Jid, jobDataFromDB)
+		if err != nil {
+			log.Error().Err(err).Uint("job id", application.Jid).Msg("error in caching the job")
+		}
+		jobData = jobDataFromDB
+
+	} else {
+		err = json.Unmarshal([]byte(val), &jobData)
+		if err != nil {
+			log.Error().Err(err).Msg("error in un marshaling")
+			result.Outcome = model.OutcomeInternalError
+			result.Error = "could not read the job"
+			return result
+		}
+	}
+	if recruiterID != 0 {
+		_, err = s.checkCompanyMember(jobData.Cid, recruiterID, model.MemberRoleOwner, model.MemberRoleRecruiter)
+		if err != nil {
+			log.Error().Err(err).Uint("job id", application.Jid).Uint("user id", recruiterID).Msg("user cannot process applications for the job")
+			result.Outcome = model.OutcomeForbidden
+			result.Error = "not allowed to process applications for this job"
+			return result
+		}
+	}
+	if jobData.Closed {
+		result.Outcome = model.OutcomeJobClosed
+		result.Error = "job is closed for applications"
+		return result
+	}
+	if !jobData.Listed(time.Now()) {
+		result.Outcome = model.OutcomeJobClosed
+		result.Error = "job is not published"
+		return result
 	}
 
-	return finalData
-}
+	match := CompareData(application, jobData)
 
-func CompareData(application model.NewUserApplication, jobData model.Job) bool {
-	totalFields := 0
-	matchedFields := 0
+	status := model.ApplicationApplied
+	if !match.Accepted {
+		status = model.ApplicationRejected
+	}
 
-	totalFields++
-	if application.Jobs.NoticePeriod >= jobData.MinNoticePeriod && application.Jobs.NoticePeriod <= int(jobData.MaxNoticePeriod) {
-		matchedFields++
+	//every processed application is stored along with the decision
+	applicationData, err := s.appRepo.CreateApplication(model.Application{
+		Jid:      application.Jid,
+		UserID:   application.UserID,
+		Name:     application.Name,
+		Age:      application.Age,
+		Details:  application.Jobs,
+		Accepted: match.Accepted,
+		Score:    match.Score,
+		Report:   match.Report,
+		Status:   status,
+	})
+	if errors.Is(err, repository.ErrDuplicate) {
+		result.Outcome = model.OutcomeDuplicate
+		result.Error = "already applied to this job"
+		return result
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error in saving application")
+		result.Outcome = model.OutcomeInternalError
+		result.Error = "could not save the application"
+		return result
 	}
 
-	totalFields++
-	if application.Jobs.Experience >= jobData.MinExperience && application.Jobs.Experience <= int(jobData.MaxExperience) {
-		matchedFields++
+	result.ApplicationID = applicationData.ID
+	result.Score = match.Score
+	result.Accepted = match.Accepted
+	result.Report = match.Report
+	result.Outcome = model.OutcomeRejected
+	if match.Accepted {
+		result.Outcome = model.OutcomeAccepted
 	}
 
-	count := 0
-	totalFields++
-	for _, v := range application.Jobs.Location {
-		for _, v1 := range jobData.Location {
-			if v == v1.ID {
-				count++
-			}
+	return result
+}
+
+// defaultMatchCriteria weighs every criterion equally and accepts half of them matching,
+// which is how applications were matched before criteria could be configured per job
+func defaultMatchCriteria() model.MatchCriteria {
+	return model.MatchCriteria{
+		Weights: map[string]int{
+			model.CriterionNoticePeriod:    1,
+			model.CriterionExperience:      1,
+			model.CriterionLocation:        1,
+			model.CriterionTechnologyStack: 1,
+			model.CriterionQualifications:  1,
+			model.CriterionShift:           1,
+			model.CriterionJobType:         1,
+		},
+		MinScore: 50,
+	}
+}
+
+func CompareData(application model.NewUserApplication, jobData model.Job) model.MatchResult {
+	//a job without weights weighs every criterion equally but keeps its own must haves and minimum
+	//score, only a job which configured nothing gets the default minimum score as well
+	criteria := jobData.Criteria
+	if len(criteria.Weights) == 0 {
+		defaults := defaultMatchCriteria()
+		criteria.Weights = defaults.Weights
+		if len(criteria.MustHave) == 0 && criteria.MinScore == 0 {
+			criteria.MinScore = defaults.MinScore
 		}
 	}
-	if count != 0 {
-		matchedFields++
+
+	var report model.MatchReport
+
+	addResult := func(criterion string, matched bool, provided interface{}, required interface{}) {
+		report = append(report, model.CriterionResult{
+			Criterion: criterion,
+			Matched:   matched,
+			Weight:    criteria.Weights[criterion],
+			Provided:  provided,
+			Required:  required,
+		})
 	}
 
-	count = 0
-	totalFields++
-	for _, v := range application.Jobs.TechnologyStack {
-		for _, v1 := range jobData.TechnologyStack {
-			if v == v1.ID {
-				count++
-			}
-		}
+	addResult(model.CriterionNoticePeriod,
+		application.Jobs.NoticePeriod >= jobData.MinNoticePeriod && application.Jobs.NoticePeriod <= int(jobData.MaxNoticePeriod),
+		application.Jobs.NoticePeriod,
+		model.Range{Min: jobData.MinNoticePeriod, Max: int(jobData.MaxNoticePeriod)})
+
+	addResult(model.CriterionExperience,
+		application.Jobs.Experience >= jobData.MinExperience && application.Jobs.Experience <= int(jobData.MaxExperience),
+		application.Jobs.Experience,
+		model.Range{Min: jobData.MinExperience, Max: int(jobData.MaxExperience)})
+
+	var ids []uint
+	for _, v := range jobData.Location {
+		ids = append(ids, v.ID)
 	}
-	if count != 0 {
-		matchedFields++
+	addResult(model.CriterionLocation, matchAny(application.Jobs.Location, ids), application.Jobs.Location, ids)
+
+	ids = nil
+	for _, v := range jobData.TechnologyStack {
+		ids = append(ids, v.ID)
 	}
+	addResult(model.CriterionTechnologyStack, matchAny(application.Jobs.TechnologyStack, ids), application.Jobs.TechnologyStack, ids)
 
-	count = 0
-	totalFields++
-	for _, v := range application.Jobs.Qualifications {
-		for _, v1 := range jobData.Qualifications {
-			if v == v1.ID {
-				count++
-			}
-		}
+	ids = nil
+	for _, v := range jobData.Qualifications {
+		ids = append(ids, v.ID)
+	}
+	addResult(model.CriterionQualifications, matchAny(application.Jobs.Qualifications, ids), application.Jobs.Qualifications, ids)
+
+	ids = nil
+	for _, v := range jobData.Shift {
+		ids = append(ids, v.ID)
 	}
-	if count != 0 {
-		matchedFields++
+	addResult(model.CriterionShift, matchAny(application.Jobs.Shift, ids), application.Jobs.Shift, ids)
+
+	ids = nil
+	for _, v := range jobData.Jobtype {
+		ids = append(ids, v.ID)
+	}
+	addResult(model.CriterionJobType, matchAny(application.Jobs.Jobtype, ids), application.Jobs.Jobtype, ids)
+
+	//salary and work mode are left out unless the job asks for them so older jobs score as before
+	//a job without a maximum salary has no upper bound
+	if usesCriterion(criteria, model.CriterionSalary) {
+		addResult(model.CriterionSalary,
+			application.Jobs.ExpectedSalary > 0 && (jobData.MaxSalary == 0 || application.Jobs.ExpectedSalary <= jobData.MaxSalary),
+			application.Jobs.ExpectedSalary,
+			model.Range{Min: jobData.MinSalary, Max: jobData.MaxSalary})
 	}
 
-	count = 0
-	totalFields++
-	for _, v := range application.Jobs.Shift {
-		for _, v1 := range jobData.Shift {
-			if v == v1.ID {
-				count++
+	if usesCriterion(criteria, model.CriterionWorkMode) {
+		matched := false
+		for _, v := range application.Jobs.WorkMode {
+			if v == jobData.WorkMode {
+				matched = true
 			}
 		}
-	}
-	if count != 0 {
-		matchedFields++
+		addResult(model.CriterionWorkMode, matched, application.Jobs.WorkMode, jobData.WorkMode)
 	}
 
-	count = 0
-	totalFields++
-	for _, v := range application.Jobs.Jobtype {
-		for _, v1 := range jobData.Jobtype {
-			if v == v1.ID {
-				count++
+	for _, criterion := range criteria.MustHave {
+		for i := range report {
+			if report[i].Criterion == criterion {
+				report[i].MustHave = true
 			}
 		}
 	}
-	if count != 0 {
-		matchedFields++
+
+	totalWeight := 0
+	matchedWeight := 0
+	accepted := true
+	for _, v := range report {
+		totalWeight += v.Weight
+		if v.Matched {
+			matchedWeight += v.Weight
+		}
+		if v.MustHave && !v.Matched {
+			accepted = false
+		}
 	}
 
-	if matchedFields*2 >= totalFields {
+	score := 100
+	if totalWeight > 0 {
+		score = (matchedWeight*100 + totalWeight/2) / totalWeight
+	}
+
+	if score < criteria.MinScore {
+		accepted = false
+	}
+
+	return model.MatchResult{
+		Score:    score,
+		Accepted: accepted,
+		Report:   report,
+	}
+}
+
+// usesCriterion reports whether the criterion is weighed or must have
+func usesCriterion(criteria model.MatchCriteria, criterion string) bool {
+	if _, ok := criteria.Weights[criterion]; ok {
 		return true
 	}
+	for _, v := range criteria.MustHave {
+		if v == criterion {
+			return true
+		}
+	}
+	return false
+}
 
+// matchAny reports whether any of the ids in the application is one of the ids required by the job
+func matchAny(applicationIDs []uint, jobIDs []uint) bool {
+	for _, v := range applicationIDs {
+		for _, v1 := range jobIDs {
+			if v == v1 {
+				return true
+			}
+		}
+	}
 	return false
 }
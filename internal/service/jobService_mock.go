@@ -39,75 +39,164 @@ func (m *MockJobService) EXPECT() *MockJobServiceMockRecorder {
 }
 
 // CreateJobByCompanyId mocks base method.
-func (m *MockJobService) CreateJobByCompanyId(jobdata model.NewJobs, cID uint) (model.Response, error) {
+func (m *MockJobService) CreateJobByCompanyId(jobdata model.NewJobs, cID, uID uint) (model.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateJobByCompanyId", jobdata, cID)
+	ret := m.ctrl.Call(m, "CreateJobByCompanyId", jobdata, cID, uID)
 	ret0, _ := ret[0].(model.Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateJobByCompanyId indicates an expected call of CreateJobByCompanyId.
-func (mr *MockJobServiceMockRecorder) CreateJobByCompanyId(jobdata, cID any) *gomock.Call {
+func (mr *MockJobServiceMockRecorder) CreateJobByCompanyId(jobdata, cID, uID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJobByCompanyId", reflect.TypeOf((*MockJobService)(nil).CreateJobByCompanyId), jobdata, cID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJobByCompanyId", reflect.TypeOf((*MockJobService)(nil).CreateJobByCompanyId), jobdata, cID, uID)
+}
+
+// DeleteJob mocks base method.
+func (m *MockJobService) DeleteJob(jID, uID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteJob", jID, uID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteJob indicates an expected call of DeleteJob.
+func (mr *MockJobServiceMockRecorder) DeleteJob(jID, uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJob", reflect.TypeOf((*MockJobService)(nil).DeleteJob), jID, uID)
 }
 
 // ProcessApplication mocks base method.
-func (m *MockJobService) ProcessApplication(applications []model.NewUserApplication) []model.NewUserApplication {
+func (m *MockJobService) ProcessApplication(uID uint, applications []model.NewUserApplication) []model.ProcessedApplication {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProcessApplication", applications)
-	ret0, _ := ret[0].([]model.NewUserApplication)
+	ret := m.ctrl.Call(m, "ProcessApplication", uID, applications)
+	ret0, _ := ret[0].([]model.ProcessedApplication)
 	return ret0
 }
 
 // ProcessApplication indicates an expected call of ProcessApplication.
-func (mr *MockJobServiceMockRecorder) ProcessApplication(applications any) *gomock.Call {
+func (mr *MockJobServiceMockRecorder) ProcessApplication(uID, applications any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessApplication", reflect.TypeOf((*MockJobService)(nil).ProcessApplication), uID, applications)
+}
+
+// ReplaceJob mocks base method.
+func (m *MockJobService) ReplaceJob(jID, uID uint, jobDetails model.NewJobs) (model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceJob", jID, uID, jobDetails)
+	ret0, _ := ret[0].(model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceJob indicates an expected call of ReplaceJob.
+func (mr *MockJobServiceMockRecorder) ReplaceJob(jID, uID, jobDetails any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceJob", reflect.TypeOf((*MockJobService)(nil).ReplaceJob), jID, uID, jobDetails)
+}
+
+// SearchJobs mocks base method.
+func (m *MockJobService) SearchJobs(search model.JobSearch) (model.JobSearchList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchJobs", search)
+	ret0, _ := ret[0].(model.JobSearchList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchJobs indicates an expected call of SearchJobs.
+func (mr *MockJobServiceMockRecorder) SearchJobs(search any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchJobs", reflect.TypeOf((*MockJobService)(nil).SearchJobs), search)
+}
+
+// SetJobClosed mocks base method.
+func (m *MockJobService) SetJobClosed(jID, uID uint, closed bool) (model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetJobClosed", jID, uID, closed)
+	ret0, _ := ret[0].(model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetJobClosed indicates an expected call of SetJobClosed.
+func (mr *MockJobServiceMockRecorder) SetJobClosed(jID, uID, closed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetJobClosed", reflect.TypeOf((*MockJobService)(nil).SetJobClosed), jID, uID, closed)
+}
+
+// SetJobStatus mocks base method.
+func (m *MockJobService) SetJobStatus(jID, uID uint, status string) (model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetJobStatus", jID, uID, status)
+	ret0, _ := ret[0].(model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetJobStatus indicates an expected call of SetJobStatus.
+func (mr *MockJobServiceMockRecorder) SetJobStatus(jID, uID, status any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessApplication", reflect.TypeOf((*MockJobService)(nil).ProcessApplication), applications)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetJobStatus", reflect.TypeOf((*MockJobService)(nil).SetJobStatus), jID, uID, status)
+}
+
+// UpdateJob mocks base method.
+func (m *MockJobService) UpdateJob(jID, uID uint, update model.UpdateJob) (model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJob", jID, uID, update)
+	ret0, _ := ret[0].(model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateJob indicates an expected call of UpdateJob.
+func (mr *MockJobServiceMockRecorder) UpdateJob(jID, uID, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJob", reflect.TypeOf((*MockJobService)(nil).UpdateJob), jID, uID, update)
 }
 
 // ViewAllJobs mocks base method.
-func (m *MockJobService) ViewAllJobs() ([]model.Job, error) {
+func (m *MockJobService) ViewAllJobs(filter model.JobFilter) (model.JobList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ViewAllJobs")
-	ret0, _ := ret[0].([]model.Job)
+	ret := m.ctrl.Call(m, "ViewAllJobs", filter)
+	ret0, _ := ret[0].(model.JobList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ViewAllJobs indicates an expected call of ViewAllJobs.
-func (mr *MockJobServiceMockRecorder) ViewAllJobs() *gomock.Call {
+func (mr *MockJobServiceMockRecorder) ViewAllJobs(filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewAllJobs", reflect.TypeOf((*MockJobService)(nil).ViewAllJobs))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewAllJobs", reflect.TypeOf((*MockJobService)(nil).ViewAllJobs), filter)
 }
 
 // ViewJobByCompanyID mocks base method.
-func (m *MockJobService) ViewJobByCompanyID(cID uint) ([]model.Job, error) {
+func (m *MockJobService) ViewJobByCompanyID(cID, uID uint) ([]model.Job, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ViewJobByCompanyID", cID)
+	ret := m.ctrl.Call(m, "ViewJobByCompanyID", cID, uID)
 	ret0, _ := ret[0].([]model.Job)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ViewJobByCompanyID indicates an expected call of ViewJobByCompanyID.
-func (mr *MockJobServiceMockRecorder) ViewJobByCompanyID(cID any) *gomock.Call {
+func (mr *MockJobServiceMockRecorder) ViewJobByCompanyID(cID, uID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewJobByCompanyID", reflect.TypeOf((*MockJobService)(nil).ViewJobByCompanyID), cID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewJobByCompanyID", reflect.TypeOf((*MockJobService)(nil).ViewJobByCompanyID), cID, uID)
 }
 
 // ViewJobByJobID mocks base method.
-func (m *MockJobService) ViewJobByJobID(jID uint) (model.Job, error) {
+func (m *MockJobService) ViewJobByJobID(jID, uID uint) (model.Job, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ViewJobByJobID", jID)
+	ret := m.ctrl.Call(m, "ViewJobByJobID", jID, uID)
 	ret0, _ := ret[0].(model.Job)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ViewJobByJobID indicates an expected call of ViewJobByJobID.
-func (mr *MockJobServiceMockRecorder) ViewJobByJobID(jID any) *gomock.Call {
+func (mr *MockJobServiceMockRecorder) ViewJobByJobID(jID, uID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewJobByJobID", reflect.TypeOf((*MockJobService)(nil).ViewJobByJobID), jID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewJobByJobID", reflect.TypeOf((*MockJobService)(nil).ViewJobByJobID), jID, uID)
 }
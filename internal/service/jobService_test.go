@@ -7,8 +7,10 @@ import (
 	"job-portal-api/internal/repository"
 	"reflect"
 	"testing"
+	"time"
 
 	gomock "go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
 func TestService_CreateJobByCompanyId(t *testing.T) {
@@ -21,13 +23,78 @@ func TestService_CreateJobByCompanyId(t *testing.T) {
 		args         args
 		want         model.Response
 		wantErr      bool
+		wantInvalid  *model.InvalidReferences
+		mockMember   func() (model.CompanyMember, error)
+		mockCompany  error
+		mockUnusable map[string][]uint
 		mockResponse func() (model.Response, error)
 	}{
+		{
+			name:    "not a company member",
+			args:    args{jobDetails: model.NewJobs{}, cID: 1},
+			want:    model.Response{},
+			wantErr: true,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{}, errors.New("error")
+			},
+		},
+		{
+			name:    "viewer cannot post jobs",
+			args:    args{jobDetails: model.NewJobs{}, cID: 1},
+			want:    model.Response{},
+			wantErr: true,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleViewer, Status: model.MemberStatusActive}, nil
+			},
+		},
+		{
+			name:    "company does not exist",
+			args:    args{jobDetails: model.NewJobs{}, cID: 1},
+			want:    model.Response{},
+			wantErr: true,
+			wantInvalid: &model.InvalidReferences{
+				CompanyID: 1,
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil
+			},
+			mockCompany: repository.ErrNotFound,
+		},
+		{
+			name:    "company does not exist is reported before membership",
+			args:    args{jobDetails: model.NewJobs{}, cID: 1},
+			want:    model.Response{},
+			wantErr: true,
+			wantInvalid: &model.InvalidReferences{
+				CompanyID: 1,
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{}, repository.ErrNotFound
+			},
+			mockCompany: repository.ErrNotFound,
+		},
+		{
+			name:    "unknown taxonomy ids",
+			args:    args{jobDetails: model.NewJobs{Location: []uint{1, 9}, TechnologyStack: []uint{1}, Shift: []uint{7}}, cID: 1},
+			want:    model.Response{},
+			wantErr: true,
+			wantInvalid: &model.InvalidReferences{
+				Location: []uint{9},
+				Shift:    []uint{7},
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil
+			},
+			mockUnusable: map[string][]uint{model.TaxonomyLocation: {9}, model.TaxonomyShift: {7}},
+		},
 		{
 			name:    "failure",
-			args:    args{jobDetails: model.NewJobs{}, cID: 0},
+			args:    args{jobDetails: model.NewJobs{}, cID: 1},
 			want:    model.Response{},
 			wantErr: true,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleRecruiter, Status: model.MemberStatusActive}, nil
+			},
 			mockResponse: func() (model.Response, error) {
 				return model.Response{}, errors.New("error")
 			},
@@ -49,6 +116,9 @@ func TestService_CreateJobByCompanyId(t *testing.T) {
 			}, cID: 1},
 			want:    model.Response{},
 			wantErr: false,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil
+			},
 			mockResponse: func() (model.Response, error) {
 				return model.Response{}, nil
 			},
@@ -58,16 +128,32 @@ func TestService_CreateJobByCompanyId(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mc := gomock.NewController(t)
 			mj := repository.NewMockJobRepository(mc)
+			mm := repository.NewMockMemberRepository(mc)
 			mca := cache.NewMockCaching(mc)
-			s, _ := NewJobService(mj, mca)
+			mt := repository.NewMockTaxonomyRepository(mc)
+			mcom := repository.NewMockComapnyRepo(mc)
+			s, _ := NewJobService(mj, mm, repository.NewMockApplicationRepository(mc), mt, mcom, mca)
+			if tt.mockMember != nil {
+				mm.EXPECT().GetMember(gomock.Any(), gomock.Any()).Return(tt.mockMember()).AnyTimes()
+			}
+			mcom.EXPECT().GetCompanyByID(gomock.Any()).Return(model.Company{}, tt.mockCompany).AnyTimes()
+			mt.EXPECT().GetUnusableTaxonomyIDs(gomock.Any(), gomock.Any()).DoAndReturn(func(kind string, ids []uint) ([]uint, error) {
+				return tt.mockUnusable[kind], nil
+			}).AnyTimes()
 			if tt.mockResponse != nil {
 				mj.EXPECT().CreateJob(gomock.Any()).Return(tt.mockResponse()).AnyTimes()
 			}
-			got, err := s.CreateJobByCompanyId(tt.args.jobDetails, tt.args.cID)
+			got, err := s.CreateJobByCompanyId(tt.args.jobDetails, tt.args.cID, 1)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Service.UserSignup() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantInvalid != nil {
+				var refErr *InvalidReferencesError
+				if !errors.As(err, &refErr) || !reflect.DeepEqual(refErr.References, *tt.wantInvalid) {
+					t.Errorf("Service.CreateJobByCompanyId() error = %v, want invalid references %v", err, *tt.wantInvalid)
+				}
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Service.UserSignup() = %v, want %v", got, tt.want)
 			}
@@ -80,41 +166,66 @@ func TestService_ViewJobByCompanyID(t *testing.T) {
 		cID uint
 	}
 	tests := []struct {
-		name         string
-		args         args
-		want         []model.Job
-		wantErr      bool
-		mockResponse func() ([]model.Job, error)
+		name           string
+		args           args
+		want           []model.Job
+		wantErr        bool
+		wantListedOnly bool
+		mockMember     func() (model.CompanyMember, error)
+		mockResponse   func() ([]model.Job, error)
 	}{
 		{
-			name:    "failure - 1",
-			args:    args{cID: 0},
-			want:    nil,
-			wantErr: true,
+			name:           "failure - 1",
+			args:           args{cID: 0},
+			want:           nil,
+			wantErr:        true,
+			wantListedOnly: true,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{}, errors.New("error")
+			},
 			mockResponse: func() ([]model.Job, error) {
 				return nil, errors.New("error")
 			},
 		},
 		{
-			name:    "success",
-			args:    args{cID: 0},
-			want:    []model.Job{},
-			wantErr: false,
+			name:           "success",
+			args:           args{cID: 0},
+			want:           []model.Job{},
+			wantErr:        false,
+			wantListedOnly: true,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{}, errors.New("error")
+			},
 			mockResponse: func() ([]model.Job, error) {
 				return []model.Job{}, nil
 			},
 		},
+		{
+			name:           "members see every job",
+			args:           args{cID: 1},
+			want:           []model.Job{{Cid: 1, Status: model.JobStatusDraft}},
+			wantErr:        false,
+			wantListedOnly: false,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleViewer, Status: model.MemberStatusActive}, nil
+			},
+			mockResponse: func() ([]model.Job, error) {
+				return []model.Job{{Cid: 1, Status: model.JobStatusDraft}}, nil
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mc := gomock.NewController(t)
 			mj := repository.NewMockJobRepository(mc)
+			mm := repository.NewMockMemberRepository(mc)
 			mca := cache.NewMockCaching(mc)
-			s, _ := NewJobService(mj, mca)
+			s, _ := NewJobService(mj, mm, repository.NewMockApplicationRepository(mc), repository.NewMockTaxonomyRepository(mc), repository.NewMockComapnyRepo(mc), mca)
+			mm.EXPECT().GetMember(gomock.Any(), gomock.Any()).Return(tt.mockMember()).AnyTimes()
 			if tt.mockResponse != nil {
-				mj.EXPECT().GetJobByCompanyID(gomock.Any()).Return(tt.mockResponse()).AnyTimes()
+				mj.EXPECT().GetJobByCompanyID(gomock.Any(), tt.wantListedOnly).Return(tt.mockResponse()).AnyTimes()
 			}
-			got, err := s.ViewJobByCompanyID(tt.args.cID)
+			got, err := s.ViewJobByCompanyID(tt.args.cID, 1)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Service.UserSignup() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -130,11 +241,13 @@ func TestService_ViewJobByJobID(t *testing.T) {
 	type args struct {
 		jID uint
 	}
+	past := time.Now().Add(-time.Hour)
 	tests := []struct {
 		name         string
 		args         args
 		want         model.Job
 		wantErr      bool
+		mockMember   func() (model.CompanyMember, error)
 		mockResponse func() (model.Job, error)
 	}{
 		{
@@ -146,6 +259,39 @@ func TestService_ViewJobByJobID(t *testing.T) {
 				return model.Job{}, errors.New("error")
 			},
 		},
+		{
+			name:    "draft is hidden outside the company",
+			args:    args{jID: 1},
+			want:    model.Job{},
+			wantErr: true,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{}, errors.New("error")
+			},
+			mockResponse: func() (model.Job, error) {
+				return model.Job{Cid: 1, Status: model.JobStatusDraft}, nil
+			},
+		},
+		{
+			name:    "draft is shown to the company",
+			args:    args{jID: 1},
+			want:    model.Job{Cid: 1, Status: model.JobStatusDraft},
+			wantErr: false,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleRecruiter, Status: model.MemberStatusActive}, nil
+			},
+			mockResponse: func() (model.Job, error) {
+				return model.Job{Cid: 1, Status: model.JobStatusDraft}, nil
+			},
+		},
+		{
+			name:    "published job past its expiry is expired",
+			args:    args{jID: 1},
+			want:    model.Job{Cid: 1, Status: model.JobStatusExpired, ExpiresAt: &past},
+			wantErr: false,
+			mockResponse: func() (model.Job, error) {
+				return model.Job{Cid: 1, Status: model.JobStatusPublished, ExpiresAt: &past}, nil
+			},
+		},
 		{
 			name:    "success",
 			args:    args{jID: 0},
@@ -160,12 +306,16 @@ func TestService_ViewJobByJobID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mc := gomock.NewController(t)
 			mj := repository.NewMockJobRepository(mc)
+			mm := repository.NewMockMemberRepository(mc)
 			mca := cache.NewMockCaching(mc)
-			s, _ := NewJobService(mj, mca)
+			s, _ := NewJobService(mj, mm, repository.NewMockApplicationRepository(mc), repository.NewMockTaxonomyRepository(mc), repository.NewMockComapnyRepo(mc), mca)
+			if tt.mockMember != nil {
+				mm.EXPECT().GetMember(gomock.Any(), gomock.Any()).Return(tt.mockMember()).AnyTimes()
+			}
 			if tt.mockResponse != nil {
 				mj.EXPECT().GetJobByJobID(gomock.Any()).Return(tt.mockResponse()).AnyTimes()
 			}
-			got, err := s.ViewJobByJobID(tt.args.jID)
+			got, err := s.ViewJobByJobID(tt.args.jID, 1)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Service.UserSignup() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -180,24 +330,544 @@ func TestService_ViewJobByJobID(t *testing.T) {
 func TestService_ViewAllJobs(t *testing.T) {
 	tests := []struct {
 		name         string
-		want         []model.Job
+		filter       model.JobFilter
+		want         model.JobList
 		wantErr      bool
-		mockResponse func() ([]model.Job, error)
+		mockFilter   model.JobFilter
+		mockResponse func() ([]model.Job, int64, error)
 	}{
 		{
-			name:    "failure",
-			want:    nil,
+			name:       "failure",
+			want:       model.JobList{},
+			wantErr:    true,
+			mockFilter: model.JobFilter{Page: 1, PageSize: defaultJobPageSize},
+			mockResponse: func() ([]model.Job, int64, error) {
+				return nil, 0, errors.New("error")
+			},
+		},
+		{
+			name:       "default page",
+			want:       model.JobList{Jobs: []model.Job{}, Total: 0, Page: 1, PageSize: defaultJobPageSize},
+			wantErr:    false,
+			mockFilter: model.JobFilter{Page: 1, PageSize: defaultJobPageSize},
+			mockResponse: func() ([]model.Job, int64, error) {
+				return []model.Job{}, 0, nil
+			},
+		},
+		{
+			name:       "requested page",
+			filter:     model.JobFilter{Page: 3, PageSize: 1, CompanyID: 2, Sort: model.JobSortName},
+			want:       model.JobList{Jobs: []model.Job{{Jobname: "developer"}}, Total: 5, Page: 3, PageSize: 1},
+			wantErr:    false,
+			mockFilter: model.JobFilter{Page: 3, PageSize: 1, CompanyID: 2, Sort: model.JobSortName},
+			mockResponse: func() ([]model.Job, int64, error) {
+				return []model.Job{{Jobname: "developer"}}, 5, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mj := repository.NewMockJobRepository(mc)
+			mca := cache.NewMockCaching(mc)
+			s, _ := NewJobService(mj, repository.NewMockMemberRepository(mc), repository.NewMockApplicationRepository(mc), repository.NewMockTaxonomyRepository(mc), repository.NewMockComapnyRepo(mc), mca)
+			if tt.mockResponse != nil {
+				mj.EXPECT().GetAllJobs(tt.mockFilter).Return(tt.mockResponse()).AnyTimes()
+			}
+			got, err := s.ViewAllJobs(tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.ViewAllJobs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.ViewAllJobs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_SearchJobs(t *testing.T) {
+	tests := []struct {
+		name         string
+		search       model.JobSearch
+		want         model.JobSearchList
+		wantErr      bool
+		mockSearch   model.JobSearch
+		mockResponse func() ([]model.JobSearchResult, int64, error)
+	}{
+		{
+			name:       "failure",
+			search:     model.JobSearch{Query: "golang"},
+			want:       model.JobSearchList{},
+			wantErr:    true,
+			mockSearch: model.JobSearch{Query: "golang", JobFilter: model.JobFilter{Page: 1, PageSize: defaultJobPageSize}},
+			mockResponse: func() ([]model.JobSearchResult, int64, error) {
+				return nil, 0, errors.New("error")
+			},
+		},
+		{
+			name:   "success",
+			search: model.JobSearch{Query: "golang", JobFilter: model.JobFilter{Page: 2, PageSize: 1, Location: []uint{1}}},
+			want: model.JobSearchList{Results: []model.JobSearchResult{{Job: model.Job{Jobname: "golang developer"}, Rank: 0.6, Snippet: "<b>golang</b>"}},
+				Total: 2, Page: 2, PageSize: 1},
+			wantErr:    false,
+			mockSearch: model.JobSearch{Query: "golang", JobFilter: model.JobFilter{Page: 2, PageSize: 1, Location: []uint{1}}},
+			mockResponse: func() ([]model.JobSearchResult, int64, error) {
+				return []model.JobSearchResult{{Job: model.Job{Jobname: "golang developer"}, Rank: 0.6, Snippet: "<b>golang</b>"}}, 2, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mj := repository.NewMockJobRepository(mc)
+			s, _ := NewJobService(mj, repository.NewMockMemberRepository(mc), repository.NewMockApplicationRepository(mc), repository.NewMockTaxonomyRepository(mc), repository.NewMockComapnyRepo(mc), cache.NewMockCaching(mc))
+			mj.EXPECT().SearchJobs(tt.mockSearch).Return(tt.mockResponse()).AnyTimes()
+			got, err := s.SearchJobs(tt.search)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.SearchJobs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.SearchJobs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_ProcessApplication(t *testing.T) {
+	jobData := model.Job{
+		MinNoticePeriod: 0,
+		MaxNoticePeriod: 30,
+		MinExperience:   1,
+		MaxExperience:   5,
+		Location:        []model.Location{{Model: gorm.Model{ID: 1}}},
+		TechnologyStack: []model.TechnologyStack{{Model: gorm.Model{ID: 1}}},
+		Status:          model.JobStatusPublished,
+		Cid:             1,
+	}
+	rejected := model.NewUserApplication{Name: "soma", Jid: 1, Jobs: model.Requestfield{
+		NoticePeriod: 60, Experience: 10, Location: []uint{2},
+	}}
+	accepted := model.NewUserApplication{Name: "soma", Jid: 1, Jobs: model.Requestfield{
+		NoticePeriod: 10, Experience: 2, Location: []uint{1}, TechnologyStack: []uint{1},
+	}}
+	withUserID := accepted
+	withUserID.UserID = 7
+	tests := []struct {
+		name         string
+		applications []model.NewUserApplication
+		want         []model.ProcessedApplication
+		mockJob      func() (model.Job, error)
+		mockMember   func() (model.CompanyMember, error)
+		mockSave     func() (model.Application, error)
+	}{
+		{
+			name:         "invalid job id",
+			applications: []model.NewUserApplication{{Name: "soma", Jid: 1}},
+			want: []model.ProcessedApplication{{Index: 0, Outcome: model.OutcomeInvalidJob, Error: "job does not exist",
+				Application: model.NewUserApplication{Name: "soma", Jid: 1}}},
+			mockJob: func() (model.Job, error) {
+				return model.Job{}, repository.ErrNotFound
+			},
+		},
+		{
+			name:         "error in fetching job",
+			applications: []model.NewUserApplication{{Name: "soma", Jid: 1}},
+			want: []model.ProcessedApplication{{Index: 0, Outcome: model.OutcomeInternalError, Error: "could not fetch the job",
+				Application: model.NewUserApplication{Name: "soma", Jid: 1}}},
+			mockJob: func() (model.Job, error) {
+				return model.Job{}, errors.New("error")
+			},
+		},
+		{
+			name:         "application rejected",
+			applications: []model.NewUserApplication{rejected},
+			want: []model.ProcessedApplication{{Index: 0, Outcome: model.OutcomeRejected, ApplicationID: 2, Score: 0,
+				Accepted: false, Application: rejected, Report: CompareData(rejected, jobData).Report}},
+			mockJob: func() (model.Job, error) {
+				return jobData, nil
+			},
+			mockSave: func() (model.Application, error) {
+				return model.Application{Model: gorm.Model{ID: 2}}, nil
+			},
+		},
+		{
+			name:         "application accepted",
+			applications: []model.NewUserApplication{accepted},
+			want: []model.ProcessedApplication{{Index: 0, Outcome: model.OutcomeAccepted, ApplicationID: 1, Score: 57,
+				Accepted: true, Application: accepted, Report: CompareData(accepted, jobData).Report}},
+			mockJob: func() (model.Job, error) {
+				return jobData, nil
+			},
+			mockSave: func() (model.Application, error) {
+				return model.Application{Model: gorm.Model{ID: 1}}, nil
+			},
+		},
+		{
+			name:         "error in saving application",
+			applications: []model.NewUserApplication{accepted},
+			want: []model.ProcessedApplication{{Index: 0, Outcome: model.OutcomeInternalError, Error: "could not save the application",
+				Application: accepted}},
+			mockJob: func() (model.Job, error) {
+				return jobData, nil
+			},
+			mockSave: func() (model.Application, error) {
+				return model.Application{}, errors.New("error")
+			},
+		},
+		{
+			name:         "job closed for applications",
+			applications: []model.NewUserApplication{accepted},
+			want: []model.ProcessedApplication{{Index: 0, Outcome: model.OutcomeJobClosed, Error: "job is closed for applications",
+				Application: accepted}},
+			mockJob: func() (model.Job, error) {
+				return model.Job{Cid: 1, Closed: true}, nil
+			},
+		},
+		{
+			name:         "job not published",
+			applications: []model.NewUserApplication{accepted},
+			want: []model.ProcessedApplication{{Index: 0, Outcome: model.OutcomeJobClosed, Error: "job is not published",
+				Application: accepted}},
+			mockJob: func() (model.Job, error) {
+				return model.Job{Cid: 1, Status: model.JobStatusDraft}, nil
+			},
+		},
+		{
+			name:         "not a recruiter of the job's company",
+			applications: []model.NewUserApplication{accepted},
+			want: []model.ProcessedApplication{{Index: 0, Outcome: model.OutcomeForbidden, Error: "not allowed to process applications for this job",
+				Application: accepted}},
+			mockJob: func() (model.Job, error) {
+				return jobData, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{}, repository.ErrNotFound
+			},
+		},
+		{
+			name:         "viewer cannot process applications",
+			applications: []model.NewUserApplication{accepted},
+			want: []model.ProcessedApplication{{Index: 0, Outcome: model.OutcomeForbidden, Error: "not allowed to process applications for this job",
+				Application: accepted}},
+			mockJob: func() (model.Job, error) {
+				return jobData, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleViewer, Status: model.MemberStatusActive}, nil
+			},
+		},
+		{
+			name:         "client supplied user id is ignored",
+			applications: []model.NewUserApplication{withUserID},
+			want: []model.ProcessedApplication{{Index: 0, Outcome: model.OutcomeAccepted, ApplicationID: 1, Score: 57,
+				Accepted: true, Application: accepted, Report: CompareData(accepted, jobData).Report}},
+			mockJob: func() (model.Job, error) {
+				return jobData, nil
+			},
+			mockSave: func() (model.Application, error) {
+				return model.Application{Model: gorm.Model{ID: 1}}, nil
+			},
+		},
+		{
+			name:         "empty batch",
+			applications: []model.NewUserApplication{},
+			want:         []model.ProcessedApplication{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mj := repository.NewMockJobRepository(mc)
+			ma := repository.NewMockApplicationRepository(mc)
+			mca := cache.NewMockCaching(mc)
+			mm := repository.NewMockMemberRepository(mc)
+			s, _ := NewJobService(mj, mm, ma, repository.NewMockTaxonomyRepository(mc), repository.NewMockComapnyRepo(mc), mca)
+			mca.EXPECT().GetTheCacheData(gomock.Any(), gomock.Any()).Return("", errors.New("cache miss")).AnyTimes()
+			mca.EXPECT().AddToTheCache(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			if tt.mockJob != nil {
+				mj.EXPECT().GetJobByJobID(gomock.Any()).Return(tt.mockJob()).AnyTimes()
+			}
+			if tt.mockMember != nil {
+				mm.EXPECT().GetMember(uint(1), uint(1)).Return(tt.mockMember()).AnyTimes()
+			} else {
+				mm.EXPECT().GetMember(uint(1), uint(1)).Return(model.CompanyMember{Role: model.MemberRoleRecruiter, Status: model.MemberStatusActive}, nil).AnyTimes()
+			}
+			if tt.mockSave != nil {
+				ma.EXPECT().CreateApplication(gomock.Cond(func(x any) bool {
+					return x.(model.Application).UserID == 0
+				})).Return(tt.mockSave()).AnyTimes()
+			}
+			got := s.ProcessApplication(1, tt.applications)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.ProcessApplication() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareData(t *testing.T) {
+	jobData := model.Job{
+		MinNoticePeriod: 0,
+		MaxNoticePeriod: 30,
+		MinExperience:   1,
+		MaxExperience:   5,
+		Location:        []model.Location{{Model: gorm.Model{ID: 1}}},
+		TechnologyStack: []model.TechnologyStack{{Model: gorm.Model{ID: 1}}},
+		Qualifications:  []model.Qualification{{Model: gorm.Model{ID: 1}}},
+		Shift:           []model.Shift{{Model: gorm.Model{ID: 1}}},
+		Jobtype:         []model.JobType{{Model: gorm.Model{ID: 1}}},
+		MinSalary:       50,
+		MaxSalary:       100,
+		WorkMode:        model.WorkModeRemote,
+	}
+	tests := []struct {
+		name         string
+		application  model.Requestfield
+		criteria     model.MatchCriteria
+		want         model.MatchResult
+		wantMissed   []string
+		wantCriteria int
+		openSalary   bool
+	}{
+		{
+			name:        "default criteria all matched",
+			application: model.Requestfield{NoticePeriod: 10, Experience: 2, Location: []uint{1}, TechnologyStack: []uint{1}, Qualifications: []uint{1}, Shift: []uint{1}, Jobtype: []uint{1}},
+			want:        model.MatchResult{Score: 100, Accepted: true},
+		},
+		{
+			name:        "default criteria less than half matched",
+			application: model.Requestfield{NoticePeriod: 10, Experience: 2, Shift: []uint{1}},
+			want:        model.MatchResult{Score: 43, Accepted: false},
+			wantMissed: []string{model.CriterionLocation, model.CriterionTechnologyStack, model.CriterionQualifications,
+				model.CriterionJobType},
+		},
+		{
+			name:        "weighted criteria",
+			application: model.Requestfield{NoticePeriod: 60, Experience: 10, TechnologyStack: []uint{1}},
+			criteria: model.MatchCriteria{
+				Weights:  map[string]int{model.CriterionTechnologyStack: 3, model.CriterionExperience: 1},
+				MinScore: 70,
+			},
+			want: model.MatchResult{Score: 75, Accepted: true},
+			wantMissed: []string{model.CriterionNoticePeriod, model.CriterionExperience, model.CriterionLocation,
+				model.CriterionQualifications, model.CriterionShift, model.CriterionJobType},
+		},
+		{
+			name:        "missing must have criterion",
+			application: model.Requestfield{NoticePeriod: 10, Experience: 2, Location: []uint{1}, Qualifications: []uint{1}, Shift: []uint{1}, Jobtype: []uint{1}},
+			criteria: model.MatchCriteria{
+				Weights:  defaultMatchCriteria().Weights,
+				MustHave: []string{model.CriterionTechnologyStack},
+				MinScore: 50,
+			},
+			want:       model.MatchResult{Score: 86, Accepted: false},
+			wantMissed: []string{model.CriterionTechnologyStack},
+		},
+		{
+			name:        "must have without weights",
+			application: model.Requestfield{NoticePeriod: 10, Experience: 2, Location: []uint{1}, Qualifications: []uint{1}, Shift: []uint{1}, Jobtype: []uint{1}},
+			criteria: model.MatchCriteria{
+				MustHave: []string{model.CriterionTechnologyStack},
+			},
+			want:       model.MatchResult{Score: 86, Accepted: false},
+			wantMissed: []string{model.CriterionTechnologyStack},
+		},
+		{
+			name:        "minimum score without weights",
+			application: model.Requestfield{NoticePeriod: 10, Experience: 2, Location: []uint{1}, Qualifications: []uint{1}, Shift: []uint{1}, Jobtype: []uint{1}},
+			criteria: model.MatchCriteria{
+				MinScore: 90,
+			},
+			want:       model.MatchResult{Score: 86, Accepted: false},
+			wantMissed: []string{model.CriterionTechnologyStack},
+		},
+		{
+			name:        "salary and work mode weighted",
+			application: model.Requestfield{ExpectedSalary: 80, WorkMode: []string{model.WorkModeHybrid}},
+			criteria: model.MatchCriteria{
+				Weights: map[string]int{model.CriterionSalary: 1, model.CriterionWorkMode: 1},
+			},
+			want: model.MatchResult{Score: 50, Accepted: true},
+			wantMissed: []string{model.CriterionExperience, model.CriterionLocation, model.CriterionTechnologyStack,
+				model.CriterionQualifications, model.CriterionShift, model.CriterionJobType, model.CriterionWorkMode},
+			wantCriteria: 9,
+		},
+		{
+			name:        "expected salary above the job must have",
+			application: model.Requestfield{NoticePeriod: 10, Experience: 2, Location: []uint{1}, TechnologyStack: []uint{1}, Qualifications: []uint{1}, Shift: []uint{1}, Jobtype: []uint{1}, ExpectedSalary: 150},
+			criteria: model.MatchCriteria{
+				Weights:  defaultMatchCriteria().Weights,
+				MustHave: []string{model.CriterionSalary},
+			},
+			want:         model.MatchResult{Score: 100, Accepted: false},
+			wantMissed:   []string{model.CriterionSalary},
+			wantCriteria: 8,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := jobData
+			job.Criteria = tt.criteria
+			got := CompareData(model.NewUserApplication{Jobs: tt.application}, job)
+			if got.Score != tt.want.Score || got.Accepted != tt.want.Accepted {
+				t.Errorf("CompareData() = %v, want %v", got, tt.want)
+			}
+			if tt.wantCriteria == 0 {
+				tt.wantCriteria = 7
+			}
+			if len(got.Report) != tt.wantCriteria {
+				t.Fatalf("CompareData() report has %d criteria, want %d", len(got.Report), tt.wantCriteria)
+			}
+			var missed []string
+			for _, v := range got.Report {
+				if !v.Matched {
+					missed = append(missed, v.Criterion)
+				}
+			}
+			if !reflect.DeepEqual(missed, tt.wantMissed) {
+				t.Errorf("CompareData() missed = %v, want %v", missed, tt.wantMissed)
+			}
+		})
+	}
+}
+
+func TestService_UpdateJob(t *testing.T) {
+	jobName := "backend developer"
+	locations := []uint{2}
+	retiredLocation := []uint{5}
+	maxSalary := 40
+	tests := []struct {
+		name       string
+		update     model.UpdateJob
+		want       model.Job
+		wantErr    error
+		mockJob    func() (model.Job, error)
+		mockMember func() (model.CompanyMember, error)
+	}{
+		{
+			name:    "job does not exist",
+			want:    model.Job{},
+			wantErr: ErrJobNotFound,
+			mockJob: func() (model.Job, error) {
+				return model.Job{}, repository.ErrNotFound
+			},
+		},
+		{
+			name:    "viewer cannot update the job",
+			want:    model.Job{},
+			wantErr: ErrNotCompanyMember,
+			mockJob: func() (model.Job, error) {
+				return model.Job{Cid: 1}, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleViewer, Status: model.MemberStatusActive}, nil
+			},
+		},
+		{
+			name:    "retired location",
+			update:  model.UpdateJob{Location: &retiredLocation},
+			want:    model.Job{},
+			wantErr: &InvalidReferencesError{},
+			mockJob: func() (model.Job, error) {
+				return model.Job{Cid: 1}, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil
+			},
+		},
+		{
+			name:    "maximum salary below the minimum",
+			update:  model.UpdateJob{MaxSalary: &maxSalary},
+			want:    model.Job{},
+			wantErr: ErrInvalidSalary,
+			mockJob: func() (model.Job, error) {
+				return model.Job{Cid: 1, MinSalary: 50, MaxSalary: 100}, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil
+			},
+		},
+		{
+			name:   "only given fields are changed",
+			update: model.UpdateJob{Jobname: &jobName, Location: &locations},
+			want: model.Job{Cid: 1, Jobname: "backend developer", Description: "go", MinExperience: 2,
+				Location: []model.Location{{Model: gorm.Model{ID: 2}}}, TechnologyStack: []model.TechnologyStack{{Model: gorm.Model{ID: 1}}}},
+			wantErr: nil,
+			mockJob: func() (model.Job, error) {
+				return model.Job{Cid: 1, Jobname: "developer", Description: "go", MinExperience: 2,
+					Location: []model.Location{{Model: gorm.Model{ID: 1}}}, TechnologyStack: []model.TechnologyStack{{Model: gorm.Model{ID: 1}}}}, nil
+			},
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleRecruiter, Status: model.MemberStatusActive}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mj := repository.NewMockJobRepository(mc)
+			mm := repository.NewMockMemberRepository(mc)
+			mca := cache.NewMockCaching(mc)
+			mt := repository.NewMockTaxonomyRepository(mc)
+			mco := repository.NewMockComapnyRepo(mc)
+			s, _ := NewJobService(mj, mm, repository.NewMockApplicationRepository(mc), mt, mco, mca)
+			mco.EXPECT().GetCompanyByID(uint64(1)).Return(model.Company{}, nil).AnyTimes()
+			mt.EXPECT().GetUnusableTaxonomyIDs(gomock.Any(), gomock.Any()).DoAndReturn(func(kind string, ids []uint) ([]uint, error) {
+				if kind == model.TaxonomyLocation && ids[0] == 5 {
+					return []uint{5}, nil
+				}
+				return nil, nil
+			}).AnyTimes()
+			mj.EXPECT().GetJobByJobID(gomock.Any()).Return(tt.mockJob()).AnyTimes()
+			if tt.mockMember != nil {
+				mm.EXPECT().GetMember(gomock.Any(), gomock.Any()).Return(tt.mockMember()).AnyTimes()
+			}
+			mj.EXPECT().UpdateJob(gomock.Any()).DoAndReturn(func(job model.Job) (model.Job, error) {
+				return job, nil
+			}).AnyTimes()
+			mca.EXPECT().DeleteTheCacheData(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			got, err := s.UpdateJob(1, 1, tt.update)
+			var refErr *InvalidReferencesError
+			if errors.As(tt.wantErr, &refErr) {
+				if !errors.As(err, &refErr) {
+					t.Errorf("Service.UpdateJob() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.UpdateJob() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.UpdateJob() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_SetJobClosed(t *testing.T) {
+	tests := []struct {
+		name      string
+		want      model.Job
+		wantErr   bool
+		mockClose func() error
+	}{
+		{
+			name:    "error in closing job",
+			want:    model.Job{},
 			wantErr: true,
-			mockResponse: func() ([]model.Job, error) {
-				return nil, errors.New("error")
+			mockClose: func() error {
+				return errors.New("error")
 			},
 		},
 		{
 			name:    "success",
-			want:    []model.Job{},
+			want:    model.Job{Cid: 1, Closed: true},
 			wantErr: false,
-			mockResponse: func() ([]model.Job, error) {
-				return []model.Job{}, nil
+			mockClose: func() error {
+				return nil
 			},
 		},
 	}
@@ -205,18 +875,160 @@ func TestService_ViewAllJobs(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mc := gomock.NewController(t)
 			mj := repository.NewMockJobRepository(mc)
+			mm := repository.NewMockMemberRepository(mc)
 			mca := cache.NewMockCaching(mc)
-			s, _ := NewJobService(mj, mca)
-			if tt.mockResponse != nil {
-				mj.EXPECT().GetAllJobs().Return(tt.mockResponse()).AnyTimes()
-			}
-			got, err := s.ViewAllJobs()
+			mco := repository.NewMockComapnyRepo(mc)
+			s, _ := NewJobService(mj, mm, repository.NewMockApplicationRepository(mc), repository.NewMockTaxonomyRepository(mc), mco, mca)
+			mco.EXPECT().GetCompanyByID(uint64(1)).Return(model.Company{}, nil).AnyTimes()
+			mj.EXPECT().GetJobByJobID(gomock.Any()).Return(model.Job{Cid: 1}, nil).AnyTimes()
+			mm.EXPECT().GetMember(gomock.Any(), gomock.Any()).Return(model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil).AnyTimes()
+			mj.EXPECT().SetJobClosed(gomock.Any(), gomock.Any()).Return(tt.mockClose()).AnyTimes()
+			mca.EXPECT().DeleteTheCacheData(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			got, err := s.SetJobClosed(1, 1, true)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("Service.UserSignup() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("Service.SetJobClosed() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("Service.UserSignup() = %v, want %v", got, tt.want)
+				t.Errorf("Service.SetJobClosed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_SetJobStatus(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	tests := []struct {
+		name       string
+		job        model.Job
+		status     string
+		companyErr error
+		want       model.Job
+		wantErr    error
+	}{
+		{
+			name:       "company deleted",
+			job:        model.Job{Cid: 1, Status: model.JobStatusDraft},
+			status:     model.JobStatusPublished,
+			companyErr: repository.ErrNotFound,
+			want:       model.Job{},
+			wantErr:    ErrJobNotFound,
+		},
+		{
+			name:    "archived job cannot be published",
+			job:     model.Job{Cid: 1, Status: model.JobStatusArchived},
+			status:  model.JobStatusPublished,
+			want:    model.Job{},
+			wantErr: ErrInvalidJobStatus,
+		},
+		{
+			name:    "published job cannot be published again",
+			job:     model.Job{Cid: 1, Status: model.JobStatusPublished},
+			status:  model.JobStatusPublished,
+			want:    model.Job{},
+			wantErr: ErrInvalidJobStatus,
+		},
+		{
+			name:    "expired job needs a new expiry date",
+			job:     model.Job{Cid: 1, Status: model.JobStatusPublished, ExpiresAt: &past},
+			status:  model.JobStatusPublished,
+			want:    model.Job{},
+			wantErr: ErrInvalidExpiry,
+		},
+		{
+			name:    "publish draft",
+			job:     model.Job{Cid: 1, Status: model.JobStatusDraft, ExpiresAt: &future},
+			status:  model.JobStatusPublished,
+			want:    model.Job{Cid: 1, Status: model.JobStatusPublished, ExpiresAt: &future},
+			wantErr: nil,
+		},
+		{
+			name:    "archive expired job",
+			job:     model.Job{Cid: 1, Status: model.JobStatusExpired},
+			status:  model.JobStatusArchived,
+			want:    model.Job{Cid: 1, Status: model.JobStatusArchived},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mj := repository.NewMockJobRepository(mc)
+			mm := repository.NewMockMemberRepository(mc)
+			mca := cache.NewMockCaching(mc)
+			mco := repository.NewMockComapnyRepo(mc)
+			s, _ := NewJobService(mj, mm, repository.NewMockApplicationRepository(mc), repository.NewMockTaxonomyRepository(mc), mco, mca)
+			mco.EXPECT().GetCompanyByID(uint64(1)).Return(model.Company{}, tt.companyErr).AnyTimes()
+			mj.EXPECT().GetJobByJobID(gomock.Any()).Return(tt.job, nil).AnyTimes()
+			mm.EXPECT().GetMember(gomock.Any(), gomock.Any()).Return(model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil).AnyTimes()
+			mj.EXPECT().SetJobStatus(gomock.Any(), tt.status).Return(nil).AnyTimes()
+			mca.EXPECT().DeleteTheCacheData(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			got, err := s.SetJobStatus(1, 1, tt.status)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.SetJobStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.SetJobStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_DeleteJob(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantErr    error
+		mockMember func() (model.CompanyMember, error)
+		mockDelete func() error
+	}{
+		{
+			name:    "user is not a member of the company",
+			wantErr: ErrNotCompanyMember,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{}, errors.New("error")
+			},
+		},
+		{
+			name:    "job already deleted",
+			wantErr: ErrJobNotFound,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleOwner, Status: model.MemberStatusActive}, nil
+			},
+			mockDelete: func() error {
+				return repository.ErrNotFound
+			},
+		},
+		{
+			name:    "success",
+			wantErr: nil,
+			mockMember: func() (model.CompanyMember, error) {
+				return model.CompanyMember{Role: model.MemberRoleRecruiter, Status: model.MemberStatusActive}, nil
+			},
+			mockDelete: func() error {
+				return nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mj := repository.NewMockJobRepository(mc)
+			mm := repository.NewMockMemberRepository(mc)
+			mca := cache.NewMockCaching(mc)
+			mco := repository.NewMockComapnyRepo(mc)
+			s, _ := NewJobService(mj, mm, repository.NewMockApplicationRepository(mc), repository.NewMockTaxonomyRepository(mc), mco, mca)
+			mco.EXPECT().GetCompanyByID(uint64(1)).Return(model.Company{}, nil).AnyTimes()
+			mj.EXPECT().GetJobByJobID(gomock.Any()).Return(model.Job{Cid: 1}, nil).AnyTimes()
+			mm.EXPECT().GetMember(gomock.Any(), gomock.Any()).Return(tt.mockMember()).AnyTimes()
+			if tt.mockDelete != nil {
+				mj.EXPECT().DeleteJob(gomock.Any()).Return(tt.mockDelete()).AnyTimes()
+			}
+			mca.EXPECT().DeleteTheCacheData(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			err := s.DeleteJob(1, 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.DeleteJob() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -1,15 +1,96 @@
 package service
 
 import (
+	"errors"
 	"job-portal-api/internal/authentication"
 	"job-portal-api/internal/cache"
+	"job-portal-api/internal/model"
+	"job-portal-api/internal/notifier"
 	"job-portal-api/internal/repository"
+	"job-portal-api/internal/storage"
+	"job-portal-api/internal/textextract"
+	"sync"
+	"time"
 )
 
+// errors returned by the services which the handlers map to a status code
+var (
+	ErrNotCompanyMember    = errors.New("user is not allowed to manage this company")
+	ErrInvalidTransition   = errors.New("application cannot move to the requested status")
+	ErrStatusChanged       = errors.New("application status was changed meanwhile, try again")
+	ErrJobNotFound         = errors.New("job does not exist")
+	ErrCompanyNotFound     = errors.New("company does not exist")
+	ErrUnknownTaxonomy     = errors.New("unknown taxonomy")
+	ErrTaxonomyNotFound    = errors.New("taxonomy value does not exist")
+	ErrTaxonomyExists      = errors.New("taxonomy value already exists")
+	ErrEmptyTaxonomyName   = errors.New("taxonomy name cannot be blank")
+	ErrInvalidJobStatus    = errors.New("job cannot move to the requested status")
+	ErrInvalidExpiry       = errors.New("job expiry date has already passed")
+	ErrInvalidSalary       = errors.New("job maximum salary is below its minimum")
+	ErrProfileNotFound     = errors.New("candidate profile does not exist")
+	ErrUserNotFound        = errors.New("user does not exist")
+	ErrApplicationNotFound = errors.New("application does not exist")
+	ErrDocumentNotFound    = errors.New("document does not exist")
+	ErrDocumentTooLarge    = errors.New("document is larger than allowed")
+	ErrInvalidDocument     = errors.New("only pdf and docx documents are accepted")
+	ErrInvalidLink         = errors.New("download link is invalid or has expired")
+	ErrInvalidRefreshToken = errors.New("refresh token is invalid or has expired")
+	ErrInvalidResetToken   = errors.New("password reset token is invalid or has expired")
+	ErrInvalidEmailToken   = errors.New("email verification token is invalid or has expired")
+	ErrEmailVerified       = errors.New("email is already verified")
+	ErrResendThrottled     = errors.New("a verification email was sent recently, try again later")
+)
+
+// InvalidReferencesError is returned when a job or a profile refers to a company or taxonomy values which do not exist
+type InvalidReferencesError struct {
+	References model.InvalidReferences
+}
+
+func (e *InvalidReferencesError) Error() string {
+	return "request refers to values which do not exist"
+}
+
 type Service struct {
-	userRepo       repository.UserRepository
-	comapnayRepo   repository.ComapnyRepo
-	jobRepo        repository.JobRepository
-	authentication authentication.Authenticaton
-	rdb cache.Caching
+	userRepo         repository.UserRepository
+	comapnayRepo     repository.ComapnyRepo
+	jobRepo          repository.JobRepository
+	memberRepo       repository.MemberRepository
+	appRepo          repository.ApplicationRepository
+	taxonomyRepo     repository.TaxonomyRepository
+	housekeepingRepo repository.HousekeepingRepository
+	candidateRepo    repository.CandidateRepository
+	docRepo          repository.DocumentRepository
+	authentication   authentication.Authenticaton
+	rdb              cache.Caching
+	sessions         cache.SessionStore
+	accessTokenTTL   time.Duration
+	refreshTokenTTL  time.Duration
+	notifier         notifier.Notifier
+	passwordResetTTL time.Duration
+	passwordResetURL string
+	verificationTTL  time.Duration
+	verificationURL  string
+	resendInterval   time.Duration
+	storage          storage.Storage
+	signer           *storage.Signer
+	extractor        textextract.Extractor
+	maxDocumentSize  int64
+	background       *sync.WaitGroup // tracks the mails sent after the answer so shutdown can wait for them
+}
+
+// checkCompanyMember returns an error when the user is not an active member of the company
+// holding one of the given roles
+func (s *Service) checkCompanyMember(cID uint, uID uint, roles ...string) (model.CompanyMember, error) {
+	member, err := s.memberRepo.GetMember(cID, uID)
+	if err != nil || member.Status != model.MemberStatusActive {
+		return model.CompanyMember{}, ErrNotCompanyMember
+	}
+
+	for _, role := range roles {
+		if member.Role == role {
+			return member, nil
+		}
+	}
+
+	return model.CompanyMember{}, ErrNotCompanyMember
 }
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"errors"
+	"job-portal-api/internal/model"
+	"job-portal-api/internal/repository"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockgen -source=taxonomyService.go -destination=taxonomyService_mock.go -package=service
+type TaxonomyService interface {
+	ViewTaxonomies(kind string, includeRetired bool) ([]model.Taxonomy, error)
+	AddTaxonomy(kind string, taxonomy model.TaxonomyName) (model.Taxonomy, error)
+	RenameTaxonomy(kind string, id uint, taxonomy model.TaxonomyName) (model.Taxonomy, error)
+	SetTaxonomyRetired(kind string, id uint, retired bool) (model.Taxonomy, error)
+}
+
+func NewTaxonomyService(taxonomyRepo repository.TaxonomyRepository) (TaxonomyService, error) {
+	if taxonomyRepo == nil {
+		log.Info().Msg("taxonomy repo cannot be nil")
+		return nil, errors.New("taxonomy repo cannot be nil")
+	}
+	return &Service{
+		taxonomyRepo: taxonomyRepo,
+	}, nil
+}
+
+// taxonomyError maps the repository errors of a taxonomy to the service ones
+func taxonomyError(err error) error {
+	if errors.Is(err, repository.ErrUnknownTaxonomy) {
+		return ErrUnknownTaxonomy
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrTaxonomyNotFound
+	}
+	if errors.Is(err, repository.ErrDuplicate) {
+		return ErrTaxonomyExists
+	}
+	return err
+}
+
+func (s *Service) ViewTaxonomies(kind string, includeRetired bool) ([]model.Taxonomy, error) {
+
+	taxonomies, err := s.taxonomyRepo.GetTaxonomies(kind, includeRetired)
+	if err != nil {
+		return nil, taxonomyError(err)
+	}
+
+	return taxonomies, nil
+}
+
+func (s *Service) AddTaxonomy(kind string, taxonomy model.TaxonomyName) (model.Taxonomy, error) {
+
+	name := strings.TrimSpace(taxonomy.Name)
+	if name == "" {
+		return model.Taxonomy{}, ErrEmptyTaxonomyName
+	}
+
+	taxonomyData, err := s.taxonomyRepo.CreateTaxonomy(kind, name)
+	if err != nil {
+		return model.Taxonomy{}, taxonomyError(err)
+	}
+
+	return taxonomyData, nil
+}
+
+func (s *Service) RenameTaxonomy(kind string, id uint, taxonomy model.TaxonomyName) (model.Taxonomy, error) {
+
+	name := strings.TrimSpace(taxonomy.Name)
+	if name == "" {
+		return model.Taxonomy{}, ErrEmptyTaxonomyName
+	}
+
+	taxonomyData, err := s.taxonomyRepo.RenameTaxonomy(kind, id, name)
+	if err != nil {
+		return model.Taxonomy{}, taxonomyError(err)
+	}
+
+	return taxonomyData, nil
+}
+
+// SetTaxonomyRetired retires a value so it can no longer be picked or brings a retired one back
+func (s *Service) SetTaxonomyRetired(kind string, id uint, retired bool) (model.Taxonomy, error) {
+
+	taxonomyData, err := s.taxonomyRepo.SetTaxonomyRetired(kind, id, retired)
+	if err != nil {
+		return model.Taxonomy{}, taxonomyError(err)
+	}
+
+	return taxonomyData, nil
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: taxonomyService.go
+//
+// Generated by this command:
+//
+//	mockgen -source=taxonomyService.go -destination=taxonomyService_mock.go -package=service
+//
+// Package service is a generated GoMock package.
+package service
+
+import (
+	model "job-portal-api/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTaxonomyService is a mock of TaxonomyService interface.
+type MockTaxonomyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxonomyServiceMockRecorder
+}
+
+// MockTaxonomyServiceMockRecorder is the mock recorder for MockTaxonomyService.
+type MockTaxonomyServiceMockRecorder struct {
+	mock *MockTaxonomyService
+}
+
+// NewMockTaxonomyService creates a new mock instance.
+func NewMockTaxonomyService(ctrl *gomock.Controller) *MockTaxonomyService {
+	mock := &MockTaxonomyService{ctrl: ctrl}
+	mock.recorder = &MockTaxonomyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxonomyService) EXPECT() *MockTaxonomyServiceMockRecorder {
+	return m.recorder
+}
+
+// AddTaxonomy mocks base method.
+func (m *MockTaxonomyService) AddTaxonomy(kind string, taxonomy model.TaxonomyName) (model.Taxonomy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaxonomy", kind, taxonomy)
+	ret0, _ := ret[0].(model.Taxonomy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTaxonomy indicates an expected call of AddTaxonomy.
+func (mr *MockTaxonomyServiceMockRecorder) AddTaxonomy(kind, taxonomy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaxonomy", reflect.TypeOf((*MockTaxonomyService)(nil).AddTaxonomy), kind, taxonomy)
+}
+
+// RenameTaxonomy mocks base method.
+func (m *MockTaxonomyService) RenameTaxonomy(kind string, id uint, taxonomy model.TaxonomyName) (model.Taxonomy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTaxonomy", kind, id, taxonomy)
+	ret0, _ := ret[0].(model.Taxonomy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameTaxonomy indicates an expected call of RenameTaxonomy.
+func (mr *MockTaxonomyServiceMockRecorder) RenameTaxonomy(kind, id, taxonomy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTaxonomy", reflect.TypeOf((*MockTaxonomyService)(nil).RenameTaxonomy), kind, id, taxonomy)
+}
+
+// SetTaxonomyRetired mocks base method.
+func (m *MockTaxonomyService) SetTaxonomyRetired(kind string, id uint, retired bool) (model.Taxonomy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaxonomyRetired", kind, id, retired)
+	ret0, _ := ret[0].(model.Taxonomy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTaxonomyRetired indicates an expected call of SetTaxonomyRetired.
+func (mr *MockTaxonomyServiceMockRecorder) SetTaxonomyRetired(kind, id, retired any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaxonomyRetired", reflect.TypeOf((*MockTaxonomyService)(nil).SetTaxonomyRetired), kind, id, retired)
+}
+
+// ViewTaxonomies mocks base method.
+func (m *MockTaxonomyService) ViewTaxonomies(kind string, includeRetired bool) ([]model.Taxonomy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewTaxonomies", kind, includeRetired)
+	ret0, _ := ret[0].([]model.Taxonomy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewTaxonomies indicates an expected call of ViewTaxonomies.
+func (mr *MockTaxonomyServiceMockRecorder) ViewTaxonomies(kind, includeRetired any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewTaxonomies", reflect.TypeOf((*MockTaxonomyService)(nil).ViewTaxonomies), kind, includeRetired)
+}
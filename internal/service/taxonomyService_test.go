@@ -0,0 +1,162 @@
+package service
+
+import (
+	"errors"
+	"job-portal-api/internal/model"
+	"job-portal-api/internal/repository"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_ViewTaxonomies(t *testing.T) {
+	tests := []struct {
+		name         string
+		kind         string
+		want         []model.Taxonomy
+		wantErr      error
+		mockResponse func() ([]model.Taxonomy, error)
+	}{
+		{
+			name:    "unknown kind",
+			kind:    "salary",
+			want:    nil,
+			wantErr: ErrUnknownTaxonomy,
+			mockResponse: func() ([]model.Taxonomy, error) {
+				return nil, repository.ErrUnknownTaxonomy
+			},
+		},
+		{
+			name:    "success",
+			kind:    model.TaxonomyLocation,
+			want:    []model.Taxonomy{{ID: 1, Name: "bangalore"}},
+			wantErr: nil,
+			mockResponse: func() ([]model.Taxonomy, error) {
+				return []model.Taxonomy{{ID: 1, Name: "bangalore"}}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mt := repository.NewMockTaxonomyRepository(mc)
+			s, _ := NewTaxonomyService(mt)
+			mt.EXPECT().GetTaxonomies(tt.kind, false).Return(tt.mockResponse()).AnyTimes()
+			got, err := s.ViewTaxonomies(tt.kind, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.ViewTaxonomies() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.ViewTaxonomies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_AddTaxonomy(t *testing.T) {
+	errCreate := errors.New("error")
+	tests := []struct {
+		name         string
+		taxonomy     model.TaxonomyName
+		want         model.Taxonomy
+		wantErr      error
+		mockResponse func() (model.Taxonomy, error)
+	}{
+		{
+			name:     "failure",
+			taxonomy: model.TaxonomyName{Name: "golang"},
+			want:     model.Taxonomy{},
+			wantErr:  errCreate,
+			mockResponse: func() (model.Taxonomy, error) {
+				return model.Taxonomy{}, errCreate
+			},
+		},
+		{
+			name:     "blank name",
+			taxonomy: model.TaxonomyName{Name: "   "},
+			want:     model.Taxonomy{},
+			wantErr:  ErrEmptyTaxonomyName,
+			mockResponse: func() (model.Taxonomy, error) {
+				return model.Taxonomy{}, nil
+			},
+		},
+		{
+			name:     "name already exists",
+			taxonomy: model.TaxonomyName{Name: "golang"},
+			want:     model.Taxonomy{},
+			wantErr:  ErrTaxonomyExists,
+			mockResponse: func() (model.Taxonomy, error) {
+				return model.Taxonomy{}, repository.ErrDuplicate
+			},
+		},
+		{
+			name:     "name is trimmed",
+			taxonomy: model.TaxonomyName{Name: "  golang "},
+			want:     model.Taxonomy{ID: 1, Name: "golang"},
+			wantErr:  nil,
+			mockResponse: func() (model.Taxonomy, error) {
+				return model.Taxonomy{ID: 1, Name: "golang"}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mt := repository.NewMockTaxonomyRepository(mc)
+			s, _ := NewTaxonomyService(mt)
+			mt.EXPECT().CreateTaxonomy(model.TaxonomyTechnologyStack, "golang").Return(tt.mockResponse()).AnyTimes()
+			got, err := s.AddTaxonomy(model.TaxonomyTechnologyStack, tt.taxonomy)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.AddTaxonomy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.AddTaxonomy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_SetTaxonomyRetired(t *testing.T) {
+	tests := []struct {
+		name         string
+		want         model.Taxonomy
+		wantErr      error
+		mockResponse func() (model.Taxonomy, error)
+	}{
+		{
+			name:    "value does not exist",
+			want:    model.Taxonomy{},
+			wantErr: ErrTaxonomyNotFound,
+			mockResponse: func() (model.Taxonomy, error) {
+				return model.Taxonomy{}, repository.ErrNotFound
+			},
+		},
+		{
+			name:    "success",
+			want:    model.Taxonomy{ID: 1, Name: "night", Retired: true},
+			wantErr: nil,
+			mockResponse: func() (model.Taxonomy, error) {
+				return model.Taxonomy{ID: 1, Name: "night", Retired: true}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mt := repository.NewMockTaxonomyRepository(mc)
+			s, _ := NewTaxonomyService(mt)
+			mt.EXPECT().SetTaxonomyRetired(model.TaxonomyShift, uint(1), true).Return(tt.mockResponse()).AnyTimes()
+			got, err := s.SetTaxonomyRetired(model.TaxonomyShift, 1, true)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.SetTaxonomyRetired() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Service.SetTaxonomyRetired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
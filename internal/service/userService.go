@@ -1,43 +1,91 @@
 package service
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"job-portal-api/internal/authentication"
+	"job-portal-api/internal/cache"
 	"job-portal-api/internal/model"
+	"job-portal-api/internal/notifier"
 	"job-portal-api/internal/passwordhash"
 	"job-portal-api/internal/repository"
+	"net/url"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
 )
 
 //go:generate mockgen -source=userService.go -destination=userService_mock.go -package=service
 type UserService interface {
 	UserSignup(userSignup model.UserSignup) (model.User, error)
-	Userlogin(userSignin model.UserLogin) (string, error)
+	Userlogin(userSignin model.UserLogin) (model.TokenPair, error)
+	RefreshSession(ctx context.Context, refreshToken string) (model.TokenPair, error)
+	Logout(ctx context.Context, claims authentication.Claims) error
+	LogoutEverywhere(ctx context.Context, uID uint) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, reset model.ResetPasswordRequest) error
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, uID uint) error
+	UpdateUserRole(ctx context.Context, uID uint, role string) (model.User, error)
+	PromoteAdmin(ctx context.Context, email string) error
+	WaitBackground(ctx context.Context) error
 }
 
-func NewUserService(userRepo repository.UserRepository, a authentication.Authenticaton) (UserService, error) {
+// NewUserService returns the user service, mailed links are the given url with the token as a query parameter
+func NewUserService(userRepo repository.UserRepository, a authentication.Authenticaton, sessions cache.SessionStore, n notifier.Notifier,
+	accessTokenTTL time.Duration, refreshTokenTTL time.Duration, passwordResetTTL time.Duration, passwordResetURL string,
+	verificationTTL time.Duration, verificationURL string, resendInterval time.Duration) (UserService, error) {
 	if userRepo == nil {
 		return nil, errors.New("user Repo cannot be nil")
 	}
+	if sessions == nil {
+		return nil, errors.New("session store cannot be nil")
+	}
+	if n == nil {
+		return nil, errors.New("notifier cannot be nil")
+	}
 	return &Service{
-		userRepo:       userRepo,
-		authentication: a,
+		userRepo:         userRepo,
+		authentication:   a,
+		sessions:         sessions,
+		notifier:         n,
+		accessTokenTTL:   accessTokenTTL,
+		refreshTokenTTL:  refreshTokenTTL,
+		passwordResetTTL: passwordResetTTL,
+		passwordResetURL: passwordResetURL,
+		verificationTTL:  verificationTTL,
+		verificationURL:  verificationURL,
+		resendInterval:   resendInterval,
+		background:       &sync.WaitGroup{},
 	}, nil
 }
 
+// UserSignup creates the user with a pending email and sends the verification link, a failing
+// delivery does not fail the signup as the user can ask for the link again
 func (s *Service) UserSignup(userData model.UserSignup) (model.User, error) {
 	hashedPassword, err := passwordhash.HashingPassword(userData.Password)
 	if err != nil {
 		return model.User{}, err
 	}
 
+	//every account starts as a candidate, other roles are only given by an admin
 	userDetails := model.User{
-		UserName: userData.UserName,
-		EmailID:  userData.EmailID,
-		Password: hashedPassword,
+		UserName:    userData.UserName,
+		EmailID:     userData.EmailID,
+		Password:    hashedPassword,
+		Role:        model.RoleCandidate,
+		EmailStatus: model.EmailStatusPending,
 	}
 
 	userDetails, err = s.userRepo.CreateUser(userDetails)
@@ -45,34 +93,478 @@ func (s *Service) UserSignup(userData model.UserSignup) (model.User, error) {
 		return model.User{}, err
 	}
 
+	err = s.sendVerification(context.Background(), userDetails)
+	if err != nil {
+		log.Error().Err(err).Uint("user id", userDetails.ID).Msg("error in sending email verification")
+	}
+
 	return userDetails, nil
 
 }
 
-func (s *Service) Userlogin(userSignin model.UserLogin) (string, error) {
+// Userlogin starts a new session for the user and returns its first access and refresh tokens
+func (s *Service) Userlogin(userSignin model.UserLogin) (model.TokenPair, error) {
 
 	userData, err := s.userRepo.CheckUser(userSignin.EmailID)
 	if err != nil {
-		return "", err
+		return model.TokenPair{}, err
 	}
 
 	err = passwordhash.CheckingHashPassword(userSignin.Password, userData.Password)
 	if err != nil {
-		return "", err
+		return model.TokenPair{}, err
+	}
+
+	role := userData.Role
+	if role == "" {
+		role = model.RoleCandidate
+	}
+
+	session := model.Session{
+		ID:            uuid.NewString(),
+		UserID:        userData.ID,
+		Role:          role,
+		EmailVerified: userData.EmailVerified(),
+	}
+
+	pair, session, err := s.issueTokens(session)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	err = s.sessions.SaveSession(context.Background(), session, s.refreshTokenTTL)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	return pair, nil
+}
+
+// RefreshSession swaps a refresh token for a new pair, a refresh token used twice ends the session
+func (s *Service) RefreshSession(ctx context.Context, refreshToken string) (model.TokenPair, error) {
+
+	sID, _, ok := strings.Cut(refreshToken, ".")
+	if !ok {
+		return model.TokenPair{}, ErrInvalidRefreshToken
+	}
+
+	session, err := s.sessions.GetSession(ctx, sID)
+	if errors.Is(err, cache.ErrSessionNotFound) {
+		return model.TokenPair{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	presentedHash := hashToken(refreshToken)
+	if subtle.ConstantTimeCompare([]byte(presentedHash), []byte(session.RefreshHash)) != 1 {
+		log.Warn().Str("session id", sID).Uint("user id", session.UserID).Msg("refresh token reused, ending the session")
+		s.endSession(ctx, session)
+		return model.TokenPair{}, ErrInvalidRefreshToken
+	}
+
+	//a session started before the email was verified picks the verification up on its next refresh
+	if !session.EmailVerified {
+		userData, err := s.userRepo.GetUserByID(session.UserID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return model.TokenPair{}, ErrInvalidRefreshToken
+		}
+		if err != nil {
+			return model.TokenPair{}, err
+		}
+		session.EmailVerified = userData.EmailVerified()
+	}
+
+	previous := session
+	pair, session, err := s.issueTokens(session)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	err = s.sessions.ReplaceSession(ctx, session, presentedHash, s.refreshTokenTTL)
+	if errors.Is(err, cache.ErrSessionNotFound) || errors.Is(err, cache.ErrSessionChanged) {
+		return model.TokenPair{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	s.revokeAccessToken(ctx, previous.AccessJTI, previous.AccessExpiresAt)
+
+	return pair, nil
+}
+
+// Logout ends the session the access token belongs to and revokes the token itself
+func (s *Service) Logout(ctx context.Context, claims authentication.Claims) error {
+
+	uID, err := claims.UserID()
+	if err != nil {
+		return err
+	}
+
+	if claims.ExpiresAt != nil {
+		err = s.revokeUntil(ctx, claims.ID, claims.ExpiresAt.Time)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.sessions.DeleteSession(ctx, uID, claims.SessionID)
+}
+
+// LogoutEverywhere ends every session of the user along with the access tokens issued for them
+func (s *Service) LogoutEverywhere(ctx context.Context, uID uint) error {
+
+	sIDs, err := s.sessions.GetUserSessions(ctx, uID)
+	if err != nil {
+		return err
+	}
+
+	for _, sID := range sIDs {
+		session, err := s.sessions.GetSession(ctx, sID)
+		if errors.Is(err, cache.ErrSessionNotFound) {
+			session = model.Session{ID: sID, UserID: uID}
+		} else if err != nil {
+			return err
+		}
+
+		err = s.revokeUntil(ctx, session.AccessJTI, session.AccessExpiresAt)
+		if err != nil {
+			return err
+		}
+
+		err = s.sessions.DeleteSession(ctx, uID, sID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateUserRole gives the user another role, every session of the user is ended as the tokens
+// issued for them carry the old role
+func (s *Service) UpdateUserRole(ctx context.Context, uID uint, role string) (model.User, error) {
+
+	err := s.userRepo.UpdateUserRole(uID, role)
+	if errors.Is(err, repository.ErrNotFound) {
+		return model.User{}, ErrUserNotFound
+	}
+	if err != nil {
+		return model.User{}, err
+	}
+
+	err = s.LogoutEverywhere(ctx, uID)
+	if err != nil {
+		return model.User{}, err
+	}
+
+	return s.userRepo.GetUserByID(uID)
+}
+
+// PromoteAdmin makes the user with the email an admin, it is how the first admin is created
+func (s *Service) PromoteAdmin(ctx context.Context, email string) error {
+
+	userData, err := s.userRepo.CheckUser(email)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if userData.Role == model.RoleAdmin {
+		return nil
+	}
+
+	_, err = s.UpdateUserRole(ctx, userData.ID, model.RoleAdmin)
+	return err
+}
+
+// ForgotPassword sends a password reset link to the email when it belongs to a user. The reset is
+// sent in the background so the caller cannot tell from the answer or its timing whether it does
+func (s *Service) ForgotPassword(ctx context.Context, email string) error {
+
+	ctx = context.WithoutCancel(ctx)
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		err := s.sendPasswordReset(ctx, email)
+		if err != nil {
+			log.Error().Err(err).Msg("error in sending password reset")
+		}
+	}()
+
+	return nil
+}
+
+// WaitBackground blocks until the work started in the background is done or the context ends
+func (s *Service) WaitBackground(ctx context.Context) error {
+
+	done := make(chan struct{})
+	go func() {
+		s.background.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// sendPasswordReset stores a new reset for the user with the email and mails its link, an unknown
+// email and a reset asked for again within the resend interval are skipped
+func (s *Service) sendPasswordReset(ctx context.Context, email string) error {
+
+	userData, err := s.userRepo.CheckUser(email)
+	if errors.Is(err, repository.ErrNotFound) {
+		log.Info().Msg("password reset asked for an unknown email")
+		return nil
+	}
+	if err != nil {
+		return err
 	}
 
-	claims := jwt.RegisteredClaims{
-		Issuer:    "job portal project",
-		Subject:   strconv.FormatUint(uint64(userData.ID), 10),
-		Audience:  jwt.ClaimStrings{"users"},
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
+	latest, err := s.userRepo.GetLatestPasswordReset(userData.ID)
+	if err == nil && time.Since(latest.CreatedAt) < s.resendInterval {
+		log.Info().Uint("user id", userData.ID).Msg("password reset asked for again too soon")
+		return nil
+	}
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+
+	err = s.userRepo.CreatePasswordReset(model.PasswordReset{
+		UserID:    userData.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(s.passwordResetTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	link, err := tokenLink(s.passwordResetURL, token)
+	if err != nil {
+		return fmt.Errorf("invalid password reset url : %w", err)
+	}
+
+	return s.notifier.Notify(ctx, notifier.Message{
+		To:      userData.EmailID,
+		Subject: "Reset your password",
+		Body: "A password reset was requested for your account. Use the link below to choose a new password, " +
+			"it expires in " + s.passwordResetTTL.String() + ".\n\n" + link +
+			"\n\nIf you did not ask for this you can ignore this message.",
+	})
+}
+
+// ResetPassword sets the new password when the token is known, unused and not expired. Every
+// session of the user is ended so whoever knew the old password is logged out
+func (s *Service) ResetPassword(ctx context.Context, reset model.ResetPasswordRequest) error {
+
+	passwordReset, err := s.userRepo.GetPasswordReset(hashToken(reset.Token))
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+
+	if passwordReset.UsedAt != nil || !time.Now().Before(passwordReset.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	hashedPassword, err := passwordhash.HashingPassword(reset.Password)
+	if err != nil {
+		return err
+	}
+
+	err = s.userRepo.ResetPassword(passwordReset.ID, passwordReset.UserID, hashedPassword)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.LogoutEverywhere(ctx, passwordReset.UserID)
+}
+
+// VerifyEmail marks the email of the user verified when the token is known, unused and not expired
+func (s *Service) VerifyEmail(ctx context.Context, token string) error {
+
+	verification, err := s.userRepo.GetEmailVerification(hashToken(token))
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrInvalidEmailToken
+	}
+	if err != nil {
+		return err
+	}
+
+	if verification.UsedAt != nil || !time.Now().Before(verification.ExpiresAt) {
+		return ErrInvalidEmailToken
+	}
+
+	err = s.userRepo.VerifyEmail(verification.ID, verification.UserID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrInvalidEmailToken
+	}
+	return err
+}
+
+// ResendVerification sends a new verification link to a user whose email is still pending, at most
+// once every resend interval so the endpoint cannot be used to flood a mailbox
+func (s *Service) ResendVerification(ctx context.Context, uID uint) error {
+
+	userData, err := s.userRepo.GetUserByID(uID)
+	if err != nil {
+		return err
+	}
+
+	if userData.EmailVerified() {
+		return ErrEmailVerified
+	}
+
+	latest, err := s.userRepo.GetLatestEmailVerification(uID)
+	if err == nil && time.Since(latest.CreatedAt) < s.resendInterval {
+		return ErrResendThrottled
+	}
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+
+	return s.sendVerification(ctx, userData)
+}
+
+// sendVerification stores a new verification for the user and mails its link
+func (s *Service) sendVerification(ctx context.Context, userData model.User) error {
+
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+
+	err = s.userRepo.CreateEmailVerification(model.EmailVerification{
+		UserID:    userData.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(s.verificationTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	link, err := tokenLink(s.verificationURL, token)
+	if err != nil {
+		return fmt.Errorf("invalid email verification url : %w", err)
+	}
+
+	return s.notifier.Notify(ctx, notifier.Message{
+		To:      userData.EmailID,
+		Subject: "Verify your email",
+		Body: "Welcome " + userData.UserName + ", use the link below to verify your email address, " +
+			"it expires in " + s.verificationTTL.String() + ".\n\n" + link,
+	})
+}
+
+// issueTokens signs a new access token and makes a new refresh token for the session, the returned
+// session records both and has to be stored by the caller
+func (s *Service) issueTokens(session model.Session) (model.TokenPair, model.Session, error) {
+
+	now := time.Now()
+	expiresAt := now.Add(s.accessTokenTTL)
+
+	claims := authentication.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatUint(uint64(session.UserID), 10),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.NewString(),
+		},
+		Role:          session.Role,
+		EmailVerified: session.EmailVerified,
+		SessionID:     session.ID,
 	}
 
 	token, err := s.authentication.GenerateToken(claims)
+	if err != nil {
+		return model.TokenPair{}, model.Session{}, err
+	}
+
+	secret := make([]byte, 32)
+	_, err = rand.Read(secret)
+	if err != nil {
+		return model.TokenPair{}, model.Session{}, err
+	}
+	refreshToken := session.ID + "." + base64.RawURLEncoding.EncodeToString(secret)
+
+	session.RefreshHash = hashToken(refreshToken)
+	session.AccessJTI = claims.ID
+	session.AccessExpiresAt = expiresAt
+
+	return model.TokenPair{
+		AccessToken:  token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(s.accessTokenTTL.Seconds()),
+	}, session, nil
+}
+
+// endSession deletes the session and revokes its access token, errors are only logged as the
+// caller already refuses the request
+func (s *Service) endSession(ctx context.Context, session model.Session) {
+	s.revokeAccessToken(ctx, session.AccessJTI, session.AccessExpiresAt)
+
+	err := s.sessions.DeleteSession(ctx, session.UserID, session.ID)
+	if err != nil {
+		log.Error().Err(err).Str("session id", session.ID).Msg("error in deleting session")
+	}
+}
+
+func (s *Service) revokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) {
+	err := s.revokeUntil(ctx, jti, expiresAt)
+	if err != nil {
+		log.Error().Err(err).Str("jti", jti).Msg("error in revoking access token")
+	}
+}
+
+// revokeUntil revokes the access token until it expires, an expired token needs no revoking
+func (s *Service) revokeUntil(ctx context.Context, jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if jti == "" || ttl <= 0 {
+		return nil
+	}
+	return s.sessions.RevokeToken(ctx, jti, ttl)
+}
+
+// newToken returns a random token to hand out in a link, only its hash is stored
+func newToken() (string, error) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
 	if err != nil {
 		return "", err
 	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// tokenLink adds the token as a query parameter of the url
+func tokenLink(rawURL string, token string) (string, error) {
+	link, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
 
-	return token, nil
+// hashToken is what is stored of a refresh or password reset token, the token itself is only known to the client
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
@@ -9,6 +9,8 @@
 package service
 
 import (
+	context "context"
+	authentication "job-portal-api/internal/authentication"
 	model "job-portal-api/internal/model"
 	reflect "reflect"
 
@@ -38,6 +40,120 @@ func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 	return m.recorder
 }
 
+// ForgotPassword mocks base method.
+func (m *MockUserService) ForgotPassword(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockUserServiceMockRecorder) ForgotPassword(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockUserService)(nil).ForgotPassword), ctx, email)
+}
+
+// Logout mocks base method.
+func (m *MockUserService) Logout(ctx context.Context, claims authentication.Claims) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, claims)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockUserServiceMockRecorder) Logout(ctx, claims any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUserService)(nil).Logout), ctx, claims)
+}
+
+// LogoutEverywhere mocks base method.
+func (m *MockUserService) LogoutEverywhere(ctx context.Context, uID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutEverywhere", ctx, uID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutEverywhere indicates an expected call of LogoutEverywhere.
+func (mr *MockUserServiceMockRecorder) LogoutEverywhere(ctx, uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutEverywhere", reflect.TypeOf((*MockUserService)(nil).LogoutEverywhere), ctx, uID)
+}
+
+// PromoteAdmin mocks base method.
+func (m *MockUserService) PromoteAdmin(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromoteAdmin", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PromoteAdmin indicates an expected call of PromoteAdmin.
+func (mr *MockUserServiceMockRecorder) PromoteAdmin(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteAdmin", reflect.TypeOf((*MockUserService)(nil).PromoteAdmin), ctx, email)
+}
+
+// RefreshSession mocks base method.
+func (m *MockUserService) RefreshSession(ctx context.Context, refreshToken string) (model.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSession", ctx, refreshToken)
+	ret0, _ := ret[0].(model.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshSession indicates an expected call of RefreshSession.
+func (mr *MockUserServiceMockRecorder) RefreshSession(ctx, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockUserService)(nil).RefreshSession), ctx, refreshToken)
+}
+
+// ResendVerification mocks base method.
+func (m *MockUserService) ResendVerification(ctx context.Context, uID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", ctx, uID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockUserServiceMockRecorder) ResendVerification(ctx, uID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockUserService)(nil).ResendVerification), ctx, uID)
+}
+
+// ResetPassword mocks base method.
+func (m *MockUserService) ResetPassword(ctx context.Context, reset model.ResetPasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, reset)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockUserServiceMockRecorder) ResetPassword(ctx, reset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUserService)(nil).ResetPassword), ctx, reset)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockUserService) UpdateUserRole(ctx context.Context, uID uint, role string) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, uID, role)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockUserServiceMockRecorder) UpdateUserRole(ctx, uID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockUserService)(nil).UpdateUserRole), ctx, uID, role)
+}
+
 // UserSignup mocks base method.
 func (m *MockUserService) UserSignup(userSignup model.UserSignup) (model.User, error) {
 	m.ctrl.T.Helper()
@@ -54,10 +170,10 @@ func (mr *MockUserServiceMockRecorder) UserSignup(userSignup any) *gomock.Call {
 }
 
 // Userlogin mocks base method.
-func (m *MockUserService) Userlogin(userSignin model.UserLogin) (string, error) {
+func (m *MockUserService) Userlogin(userSignin model.UserLogin) (model.TokenPair, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Userlogin", userSignin)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(model.TokenPair)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -67,3 +183,31 @@ func (mr *MockUserServiceMockRecorder) Userlogin(userSignin any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Userlogin", reflect.TypeOf((*MockUserService)(nil).Userlogin), userSignin)
 }
+
+// VerifyEmail mocks base method.
+func (m *MockUserService) VerifyEmail(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockUserServiceMockRecorder) VerifyEmail(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUserService)(nil).VerifyEmail), ctx, token)
+}
+
+// WaitBackground mocks base method.
+func (m *MockUserService) WaitBackground(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitBackground", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitBackground indicates an expected call of WaitBackground.
+func (mr *MockUserServiceMockRecorder) WaitBackground(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitBackground", reflect.TypeOf((*MockUserService)(nil).WaitBackground), ctx)
+}
@@ -1,14 +1,21 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"job-portal-api/internal/authentication"
+	"job-portal-api/internal/cache"
 	"job-portal-api/internal/model"
+	"job-portal-api/internal/notifier"
+	"job-portal-api/internal/passwordhash"
 	"job-portal-api/internal/repository"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
 func TestService_UserSignup(t *testing.T) {
@@ -21,6 +28,7 @@ func TestService_UserSignup(t *testing.T) {
 		want             model.User
 		wantErr          bool
 		mockUserResponse func() (model.User, error)
+		notifyErr        error
 	}{
 		{
 			name:    "failure",
@@ -49,15 +57,36 @@ func TestService_UserSignup(t *testing.T) {
 				return model.User{UserName: "qwertyu", EmailID: "wertyui@gmail.com"}, nil
 			},
 		},
+		{
+			name:    "failing verification delivery does not fail the signup",
+			args:    args{userData: model.UserSignup{UserName: "qwertyu", EmailID: "wertyui@gmail.com", Password: "12345678"}},
+			want:    model.User{UserName: "qwertyu", EmailID: "wertyui@gmail.com"},
+			wantErr: false,
+			mockUserResponse: func() (model.User, error) {
+				return model.User{UserName: "qwertyu", EmailID: "wertyui@gmail.com"}, nil
+			},
+			notifyErr: errors.New("error"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mc := gomock.NewController(t)
 			ms := repository.NewMockUserRepository(mc)
 			ma := authentication.NewMockAuthenticaton(mc)
-			s, _ := NewUserService(ms, ma)
+			mn := notifier.NewMockNotifier(mc)
+			s, _ := NewUserService(ms, ma, cache.NewMockSessionStore(mc), mn, 15*time.Minute, time.Hour, time.Hour, "http://localhost/reset",
+				24*time.Hour, "http://localhost/verify", time.Minute)
 			if tt.mockUserResponse != nil {
-				ms.EXPECT().CreateUser(gomock.Any()).Return(tt.mockUserResponse()).AnyTimes()
+				ms.EXPECT().CreateUser(gomock.Any()).DoAndReturn(func(userData model.User) (model.User, error) {
+					if userData.EmailStatus != model.EmailStatusPending || userData.Role != model.RoleCandidate {
+						t.Errorf("Service.UserSignup() created %v", userData)
+					}
+					return tt.mockUserResponse()
+				}).AnyTimes()
+			}
+			if !tt.wantErr {
+				ms.EXPECT().CreateEmailVerification(gomock.Any()).Return(nil)
+				mn.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(tt.notifyErr)
 			}
 			got, err := s.UserSignup(tt.args.userData)
 			if (err != nil) != tt.wantErr {
@@ -147,19 +176,582 @@ func TestService_Userlogin(t *testing.T) {
 			mc := gomock.NewController(t)
 			ms := repository.NewMockUserRepository(mc)
 			ma := authentication.NewMockAuthenticaton(mc)
-			s, _ := NewUserService(ms, ma)
+			msess := cache.NewMockSessionStore(mc)
+			s, _ := NewUserService(ms, ma, msess, notifier.NewMockNotifier(mc), 15*time.Minute, time.Hour, time.Hour, "http://localhost/reset",
+				24*time.Hour, "http://localhost/verify", time.Minute)
 			if tt.mockUserResponse != nil {
 				ms.EXPECT().CheckUser(gomock.Any()).Return(tt.mockUserResponse()).AnyTimes()
 				ma.EXPECT().GenerateToken(gomock.Any()).Return(tt.mockAuth()).AnyTimes()
 			}
+			msess.EXPECT().SaveSession(gomock.Any(), gomock.Any(), time.Hour).Return(nil).AnyTimes()
 			got, err := s.Userlogin(tt.args.userSignin)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Service.UserSignup() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
+			if got.AccessToken != tt.want {
 				t.Errorf("Service.UserSignup() = %v, want %v", got, tt.want)
 			}
+			if !tt.wantErr && !strings.Contains(got.RefreshToken, ".") {
+				t.Errorf("Service.Userlogin() refresh token = %v", got.RefreshToken)
+			}
+		})
+	}
+}
+
+func TestService_RefreshSession(t *testing.T) {
+	stored := model.Session{ID: "s1", UserID: 1, Role: model.RoleCandidate, EmailVerified: true, RefreshHash: hashToken("s1.current"),
+		AccessJTI: "old-jti", AccessExpiresAt: time.Now().Add(time.Minute)}
+	tests := []struct {
+		name         string
+		refreshToken string
+		wantErr      error
+		setup        func(msess *cache.MockSessionStore, ma *authentication.MockAuthenticaton)
+	}{
+		{
+			name:         "malformed token",
+			refreshToken: "current",
+			wantErr:      ErrInvalidRefreshToken,
+			setup:        func(msess *cache.MockSessionStore, ma *authentication.MockAuthenticaton) {},
+		},
+		{
+			name:         "session ended",
+			refreshToken: "s1.current",
+			wantErr:      ErrInvalidRefreshToken,
+			setup: func(msess *cache.MockSessionStore, ma *authentication.MockAuthenticaton) {
+				msess.EXPECT().GetSession(gomock.Any(), "s1").Return(model.Session{}, cache.ErrSessionNotFound)
+			},
+		},
+		{
+			name:         "reused token ends the session",
+			refreshToken: "s1.rotated",
+			wantErr:      ErrInvalidRefreshToken,
+			setup: func(msess *cache.MockSessionStore, ma *authentication.MockAuthenticaton) {
+				msess.EXPECT().GetSession(gomock.Any(), "s1").Return(stored, nil)
+				msess.EXPECT().RevokeToken(gomock.Any(), "old-jti", gomock.Any()).Return(nil)
+				msess.EXPECT().DeleteSession(gomock.Any(), uint(1), "s1").Return(nil)
+			},
+		},
+		{
+			name:         "refreshed by another request",
+			refreshToken: "s1.current",
+			wantErr:      ErrInvalidRefreshToken,
+			setup: func(msess *cache.MockSessionStore, ma *authentication.MockAuthenticaton) {
+				msess.EXPECT().GetSession(gomock.Any(), "s1").Return(stored, nil)
+				ma.EXPECT().GenerateToken(gomock.Any()).Return("access", nil)
+				msess.EXPECT().ReplaceSession(gomock.Any(), gomock.Any(), stored.RefreshHash, time.Hour).Return(cache.ErrSessionChanged)
+			},
+		},
+		{
+			name:         "success",
+			refreshToken: "s1.current",
+			setup: func(msess *cache.MockSessionStore, ma *authentication.MockAuthenticaton) {
+				msess.EXPECT().GetSession(gomock.Any(), "s1").Return(stored, nil)
+				ma.EXPECT().GenerateToken(gomock.Any()).DoAndReturn(func(claims authentication.Claims) (string, error) {
+					if claims.Subject != "1" || claims.SessionID != "s1" || claims.ID == "" || claims.Role != model.RoleCandidate {
+						t.Errorf("Service.RefreshSession() claims = %v", claims)
+					}
+					return "access", nil
+				})
+				msess.EXPECT().ReplaceSession(gomock.Any(), gomock.Any(), stored.RefreshHash, time.Hour).DoAndReturn(
+					func(ctx context.Context, session model.Session, refreshHash string, ttl time.Duration) error {
+						if session.RefreshHash == stored.RefreshHash || session.AccessJTI == stored.AccessJTI {
+							t.Errorf("Service.RefreshSession() did not rotate the session %v", session)
+						}
+						return nil
+					})
+				msess.EXPECT().RevokeToken(gomock.Any(), "old-jti", gomock.Any()).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			msess := cache.NewMockSessionStore(mc)
+			ma := authentication.NewMockAuthenticaton(mc)
+			s, _ := NewUserService(repository.NewMockUserRepository(mc), ma, msess, notifier.NewMockNotifier(mc), 15*time.Minute, time.Hour, time.Hour, "http://localhost/reset",
+				24*time.Hour, "http://localhost/verify", time.Minute)
+			tt.setup(msess, ma)
+			got, err := s.RefreshSession(context.Background(), tt.refreshToken)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.RefreshSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && (got.AccessToken != "access" || !strings.HasPrefix(got.RefreshToken, "s1.")) {
+				t.Errorf("Service.RefreshSession() = %v", got)
+			}
+		})
+	}
+}
+
+func TestService_RefreshSession_PendingEmail(t *testing.T) {
+	stored := model.Session{ID: "s1", UserID: 1, Role: model.RoleCandidate, RefreshHash: hashToken("s1.current")}
+	tests := []struct {
+		name      string
+		user      model.User
+		userErr   error
+		wantClaim bool
+		wantErr   error
+	}{
+		{name: "still pending", user: model.User{EmailStatus: model.EmailStatusPending}},
+		{name: "verified since the login", user: model.User{EmailStatus: model.EmailStatusVerified}, wantClaim: true},
+		{name: "user deleted", userErr: repository.ErrNotFound, wantErr: ErrInvalidRefreshToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mr := repository.NewMockUserRepository(mc)
+			msess := cache.NewMockSessionStore(mc)
+			ma := authentication.NewMockAuthenticaton(mc)
+			s, _ := NewUserService(mr, ma, msess, notifier.NewMockNotifier(mc), 15*time.Minute, time.Hour, time.Hour, "http://localhost/reset",
+				24*time.Hour, "http://localhost/verify", time.Minute)
+
+			msess.EXPECT().GetSession(gomock.Any(), "s1").Return(stored, nil)
+			mr.EXPECT().GetUserByID(uint(1)).Return(tt.user, tt.userErr)
+			if tt.wantErr == nil {
+				ma.EXPECT().GenerateToken(gomock.Any()).DoAndReturn(func(claims authentication.Claims) (string, error) {
+					if claims.EmailVerified != tt.wantClaim {
+						t.Errorf("Service.RefreshSession() claims = %v", claims)
+					}
+					return "access", nil
+				})
+				msess.EXPECT().ReplaceSession(gomock.Any(), gomock.Any(), stored.RefreshHash, time.Hour).Return(nil)
+			}
+
+			_, err := s.RefreshSession(context.Background(), "s1.current")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.RefreshSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_LogoutEverywhere(t *testing.T) {
+	mc := gomock.NewController(t)
+	msess := cache.NewMockSessionStore(mc)
+	s, _ := NewUserService(repository.NewMockUserRepository(mc), authentication.NewMockAuthenticaton(mc), msess, notifier.NewMockNotifier(mc), 15*time.Minute, time.Hour, time.Hour, "http://localhost/reset",
+		24*time.Hour, "http://localhost/verify", time.Minute)
+
+	msess.EXPECT().GetUserSessions(gomock.Any(), uint(1)).Return([]string{"s1", "s2"}, nil)
+	msess.EXPECT().GetSession(gomock.Any(), "s1").Return(model.Session{ID: "s1", UserID: 1, AccessJTI: "jti-1", AccessExpiresAt: time.Now().Add(time.Minute)}, nil)
+	msess.EXPECT().GetSession(gomock.Any(), "s2").Return(model.Session{}, cache.ErrSessionNotFound)
+	msess.EXPECT().RevokeToken(gomock.Any(), "jti-1", gomock.Any()).Return(nil)
+	msess.EXPECT().DeleteSession(gomock.Any(), uint(1), "s1").Return(nil)
+	msess.EXPECT().DeleteSession(gomock.Any(), uint(1), "s2").Return(nil)
+
+	err := s.LogoutEverywhere(context.Background(), 1)
+	if err != nil {
+		t.Errorf("Service.LogoutEverywhere() error = %v", err)
+	}
+}
+
+func TestService_ForgotPassword(t *testing.T) {
+	mc := gomock.NewController(t)
+	mr := repository.NewMockUserRepository(mc)
+	s, _ := NewUserService(mr, authentication.NewMockAuthenticaton(mc), cache.NewMockSessionStore(mc), notifier.NewMockNotifier(mc),
+		15*time.Minute, time.Hour, time.Hour, "http://localhost/reset",
+		24*time.Hour, "http://localhost/verify", time.Minute)
+
+	//the lookup is held until ForgotPassword has returned, so the answer does not wait on it
+	release := make(chan struct{})
+	done := make(chan struct{})
+	mr.EXPECT().CheckUser("a@b.com").DoAndReturn(func(email string) (model.User, error) {
+		defer close(done)
+		<-release
+		return model.User{}, repository.ErrNotFound
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := s.ForgotPassword(ctx, "a@b.com")
+	cancel()
+	if err != nil {
+		t.Errorf("Service.ForgotPassword() error = %v, want nil", err)
+	}
+
+	//shutdown gives up waiting once its context ends
+	err = s.WaitBackground(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Service.WaitBackground() error = %v, want %v", err, context.Canceled)
+	}
+
+	close(release)
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Second)
+	defer waitCancel()
+	err = s.WaitBackground(waitCtx)
+	if err != nil {
+		t.Errorf("Service.WaitBackground() error = %v, want nil", err)
+	}
+
+	select {
+	case <-done:
+	default:
+		t.Errorf("Service.ForgotPassword() did not look up the email")
+	}
+}
+
+func TestService_sendPasswordReset(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(mr *repository.MockUserRepository, mn *notifier.MockNotifier)
+		wantErr bool
+	}{
+		{
+			name: "unknown email is not reported",
+			setup: func(mr *repository.MockUserRepository, mn *notifier.MockNotifier) {
+				mr.EXPECT().CheckUser("a@b.com").Return(model.User{}, repository.ErrNotFound)
+			},
+		},
+		{
+			name: "database failure",
+			setup: func(mr *repository.MockUserRepository, mn *notifier.MockNotifier) {
+				mr.EXPECT().CheckUser("a@b.com").Return(model.User{}, errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "reset asked for again too soon",
+			setup: func(mr *repository.MockUserRepository, mn *notifier.MockNotifier) {
+				mr.EXPECT().CheckUser("a@b.com").Return(model.User{Model: gorm.Model{ID: 1}, EmailID: "a@b.com"}, nil)
+				mr.EXPECT().GetLatestPasswordReset(uint(1)).Return(model.PasswordReset{Model: gorm.Model{CreatedAt: time.Now().Add(-10 * time.Second)}}, nil)
+			},
+		},
+		{
+			name: "error in fetching the latest reset",
+			setup: func(mr *repository.MockUserRepository, mn *notifier.MockNotifier) {
+				mr.EXPECT().CheckUser("a@b.com").Return(model.User{Model: gorm.Model{ID: 1}, EmailID: "a@b.com"}, nil)
+				mr.EXPECT().GetLatestPasswordReset(uint(1)).Return(model.PasswordReset{}, errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "failing delivery",
+			setup: func(mr *repository.MockUserRepository, mn *notifier.MockNotifier) {
+				mr.EXPECT().CheckUser("a@b.com").Return(model.User{Model: gorm.Model{ID: 1}, EmailID: "a@b.com"}, nil)
+				mr.EXPECT().GetLatestPasswordReset(uint(1)).Return(model.PasswordReset{}, repository.ErrNotFound)
+				mr.EXPECT().CreatePasswordReset(gomock.Any()).Return(nil)
+				mn.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(errors.New("error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			setup: func(mr *repository.MockUserRepository, mn *notifier.MockNotifier) {
+				var stored model.PasswordReset
+				mr.EXPECT().CheckUser("a@b.com").Return(model.User{Model: gorm.Model{ID: 1}, EmailID: "a@b.com"}, nil)
+				mr.EXPECT().GetLatestPasswordReset(uint(1)).Return(model.PasswordReset{Model: gorm.Model{CreatedAt: time.Now().Add(-2 * time.Minute)}}, nil)
+				mr.EXPECT().CreatePasswordReset(gomock.Any()).DoAndReturn(func(reset model.PasswordReset) error {
+					stored = reset
+					return nil
+				})
+				mn.EXPECT().Notify(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, msg notifier.Message) error {
+					//only the hash of the token in the link is stored
+					_, token, ok := strings.Cut(msg.Body, "http://localhost/reset?token=")
+					token, _, _ = strings.Cut(token, "\n")
+					if msg.To != "a@b.com" || !ok || stored.UserID != 1 || stored.TokenHash != hashToken(token) || stored.TokenHash == token {
+						t.Errorf("Service.sendPasswordReset() sent %v for %v", msg, stored)
+					}
+					return nil
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mr := repository.NewMockUserRepository(mc)
+			mn := notifier.NewMockNotifier(mc)
+			s, _ := NewUserService(mr, authentication.NewMockAuthenticaton(mc), cache.NewMockSessionStore(mc), mn, 15*time.Minute, time.Hour,
+				time.Hour, "http://localhost/reset",
+				24*time.Hour, "http://localhost/verify", time.Minute)
+			tt.setup(mr, mn)
+			err := s.(*Service).sendPasswordReset(context.Background(), "a@b.com")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.sendPasswordReset() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_ResetPassword(t *testing.T) {
+	request := model.ResetPasswordRequest{Token: "token", Password: "new password"}
+	usedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		setup   func(mr *repository.MockUserRepository, msess *cache.MockSessionStore)
+		wantErr error
+	}{
+		{
+			name: "unknown token",
+			setup: func(mr *repository.MockUserRepository, msess *cache.MockSessionStore) {
+				mr.EXPECT().GetPasswordReset(hashToken("token")).Return(model.PasswordReset{}, repository.ErrNotFound)
+			},
+			wantErr: ErrInvalidResetToken,
+		},
+		{
+			name: "expired token",
+			setup: func(mr *repository.MockUserRepository, msess *cache.MockSessionStore) {
+				mr.EXPECT().GetPasswordReset(gomock.Any()).Return(model.PasswordReset{UserID: 1, ExpiresAt: time.Now().Add(-time.Second)}, nil)
+			},
+			wantErr: ErrInvalidResetToken,
+		},
+		{
+			name: "used token",
+			setup: func(mr *repository.MockUserRepository, msess *cache.MockSessionStore) {
+				mr.EXPECT().GetPasswordReset(gomock.Any()).Return(model.PasswordReset{UserID: 1, ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}, nil)
+			},
+			wantErr: ErrInvalidResetToken,
+		},
+		{
+			name: "token used by a concurrent request",
+			setup: func(mr *repository.MockUserRepository, msess *cache.MockSessionStore) {
+				mr.EXPECT().GetPasswordReset(gomock.Any()).Return(model.PasswordReset{Model: gorm.Model{ID: 3}, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+				mr.EXPECT().ResetPassword(uint(3), uint(1), gomock.Any()).Return(repository.ErrNotFound)
+			},
+			wantErr: ErrInvalidResetToken,
+		},
+		{
+			name: "success ends every session",
+			setup: func(mr *repository.MockUserRepository, msess *cache.MockSessionStore) {
+				mr.EXPECT().GetPasswordReset(gomock.Any()).Return(model.PasswordReset{Model: gorm.Model{ID: 3}, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+				mr.EXPECT().ResetPassword(uint(3), uint(1), gomock.Any()).DoAndReturn(func(resetID uint, uID uint, hashedPassword string) error {
+					if passwordhash.CheckingHashPassword("new password", hashedPassword) != nil {
+						t.Errorf("Service.ResetPassword() stored %q", hashedPassword)
+					}
+					return nil
+				})
+				msess.EXPECT().GetUserSessions(gomock.Any(), uint(1)).Return([]string{"s1"}, nil)
+				msess.EXPECT().GetSession(gomock.Any(), "s1").Return(model.Session{ID: "s1", UserID: 1}, nil)
+				msess.EXPECT().DeleteSession(gomock.Any(), uint(1), "s1").Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mr := repository.NewMockUserRepository(mc)
+			msess := cache.NewMockSessionStore(mc)
+			s, _ := NewUserService(mr, authentication.NewMockAuthenticaton(mc), msess, notifier.NewMockNotifier(mc), 15*time.Minute, time.Hour,
+				time.Hour, "http://localhost/reset",
+				24*time.Hour, "http://localhost/verify", time.Minute)
+			tt.setup(mr, msess)
+			err := s.ResetPassword(context.Background(), request)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.ResetPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_VerifyEmail(t *testing.T) {
+	usedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		setup   func(mr *repository.MockUserRepository)
+		wantErr error
+	}{
+		{
+			name: "unknown token",
+			setup: func(mr *repository.MockUserRepository) {
+				mr.EXPECT().GetEmailVerification(hashToken("token")).Return(model.EmailVerification{}, repository.ErrNotFound)
+			},
+			wantErr: ErrInvalidEmailToken,
+		},
+		{
+			name: "expired token",
+			setup: func(mr *repository.MockUserRepository) {
+				mr.EXPECT().GetEmailVerification(gomock.Any()).Return(model.EmailVerification{UserID: 1, ExpiresAt: time.Now().Add(-time.Second)}, nil)
+			},
+			wantErr: ErrInvalidEmailToken,
+		},
+		{
+			name: "used token",
+			setup: func(mr *repository.MockUserRepository) {
+				mr.EXPECT().GetEmailVerification(gomock.Any()).Return(model.EmailVerification{UserID: 1, ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}, nil)
+			},
+			wantErr: ErrInvalidEmailToken,
+		},
+		{
+			name: "token used by a concurrent request",
+			setup: func(mr *repository.MockUserRepository) {
+				mr.EXPECT().GetEmailVerification(gomock.Any()).Return(model.EmailVerification{Model: gorm.Model{ID: 3}, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+				mr.EXPECT().VerifyEmail(uint(3), uint(1)).Return(repository.ErrNotFound)
+			},
+			wantErr: ErrInvalidEmailToken,
+		},
+		{
+			name: "success",
+			setup: func(mr *repository.MockUserRepository) {
+				mr.EXPECT().GetEmailVerification(gomock.Any()).Return(model.EmailVerification{Model: gorm.Model{ID: 3}, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+				mr.EXPECT().VerifyEmail(uint(3), uint(1)).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mr := repository.NewMockUserRepository(mc)
+			s, _ := NewUserService(mr, authentication.NewMockAuthenticaton(mc), cache.NewMockSessionStore(mc), notifier.NewMockNotifier(mc), 15*time.Minute, time.Hour,
+				time.Hour, "http://localhost/reset", 24*time.Hour, "http://localhost/verify", time.Minute)
+			tt.setup(mr)
+			err := s.VerifyEmail(context.Background(), "token")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.VerifyEmail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_ResendVerification(t *testing.T) {
+	pending := model.User{Model: gorm.Model{ID: 1}, UserName: "qwertyu", EmailID: "a@b.com", EmailStatus: model.EmailStatusPending}
+
+	tests := []struct {
+		name    string
+		setup   func(mr *repository.MockUserRepository, mn *notifier.MockNotifier)
+		wantErr error
+	}{
+		{
+			name: "already verified",
+			setup: func(mr *repository.MockUserRepository, mn *notifier.MockNotifier) {
+				mr.EXPECT().GetUserByID(uint(1)).Return(model.User{Model: gorm.Model{ID: 1}, EmailStatus: model.EmailStatusVerified}, nil)
+			},
+			wantErr: ErrEmailVerified,
+		},
+		{
+			name: "sent within the resend interval",
+			setup: func(mr *repository.MockUserRepository, mn *notifier.MockNotifier) {
+				mr.EXPECT().GetUserByID(uint(1)).Return(pending, nil)
+				mr.EXPECT().GetLatestEmailVerification(uint(1)).Return(model.EmailVerification{Model: gorm.Model{CreatedAt: time.Now().Add(-30 * time.Second)}}, nil)
+			},
+			wantErr: ErrResendThrottled,
+		},
+		{
+			name: "sent before the resend interval",
+			setup: func(mr *repository.MockUserRepository, mn *notifier.MockNotifier) {
+				mr.EXPECT().GetUserByID(uint(1)).Return(pending, nil)
+				mr.EXPECT().GetLatestEmailVerification(uint(1)).Return(model.EmailVerification{Model: gorm.Model{CreatedAt: time.Now().Add(-2 * time.Minute)}}, nil)
+				mr.EXPECT().CreateEmailVerification(gomock.Any()).Return(nil)
+				mn.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "never sent",
+			setup: func(mr *repository.MockUserRepository, mn *notifier.MockNotifier) {
+				var stored model.EmailVerification
+				mr.EXPECT().GetUserByID(uint(1)).Return(pending, nil)
+				mr.EXPECT().GetLatestEmailVerification(uint(1)).Return(model.EmailVerification{}, repository.ErrNotFound)
+				mr.EXPECT().CreateEmailVerification(gomock.Any()).DoAndReturn(func(verification model.EmailVerification) error {
+					stored = verification
+					return nil
+				})
+				mn.EXPECT().Notify(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, msg notifier.Message) error {
+					_, token, ok := strings.Cut(msg.Body, "http://localhost/verify?token=")
+					if msg.To != "a@b.com" || !ok || stored.UserID != 1 || stored.TokenHash != hashToken(token) {
+						t.Errorf("Service.ResendVerification() sent %v for %v", msg, stored)
+					}
+					return nil
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mr := repository.NewMockUserRepository(mc)
+			mn := notifier.NewMockNotifier(mc)
+			s, _ := NewUserService(mr, authentication.NewMockAuthenticaton(mc), cache.NewMockSessionStore(mc), mn, 15*time.Minute, time.Hour,
+				time.Hour, "http://localhost/reset", 24*time.Hour, "http://localhost/verify", time.Minute)
+			tt.setup(mr, mn)
+			err := s.ResendVerification(context.Background(), 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.ResendVerification() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_UpdateUserRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(mr *repository.MockUserRepository, msess *cache.MockSessionStore)
+		wantErr error
+	}{
+		{
+			name: "unknown user",
+			setup: func(mr *repository.MockUserRepository, msess *cache.MockSessionStore) {
+				mr.EXPECT().UpdateUserRole(uint(2), model.RoleRecruiter).Return(repository.ErrNotFound)
+			},
+			wantErr: ErrUserNotFound,
+		},
+		{
+			name: "success ends the sessions holding the old role",
+			setup: func(mr *repository.MockUserRepository, msess *cache.MockSessionStore) {
+				mr.EXPECT().UpdateUserRole(uint(2), model.RoleRecruiter).Return(nil)
+				msess.EXPECT().GetUserSessions(gomock.Any(), uint(2)).Return([]string{"s1"}, nil)
+				msess.EXPECT().GetSession(gomock.Any(), "s1").Return(model.Session{ID: "s1", UserID: 2}, nil)
+				msess.EXPECT().DeleteSession(gomock.Any(), uint(2), "s1").Return(nil)
+				mr.EXPECT().GetUserByID(uint(2)).Return(model.User{Role: model.RoleRecruiter}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mr := repository.NewMockUserRepository(mc)
+			msess := cache.NewMockSessionStore(mc)
+			s, _ := NewUserService(mr, authentication.NewMockAuthenticaton(mc), msess, notifier.NewMockNotifier(mc), 15*time.Minute, time.Hour,
+				time.Hour, "http://localhost/reset", 24*time.Hour, "http://localhost/verify", time.Minute)
+			tt.setup(mr, msess)
+			_, err := s.UpdateUserRole(context.Background(), 2, model.RoleRecruiter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.UpdateUserRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_PromoteAdmin(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(mr *repository.MockUserRepository, msess *cache.MockSessionStore)
+		wantErr error
+	}{
+		{
+			name: "user has not signed up",
+			setup: func(mr *repository.MockUserRepository, msess *cache.MockSessionStore) {
+				mr.EXPECT().CheckUser("admin@example.com").Return(model.User{}, repository.ErrNotFound)
+			},
+			wantErr: ErrUserNotFound,
+		},
+		{
+			name: "user is already an admin",
+			setup: func(mr *repository.MockUserRepository, msess *cache.MockSessionStore) {
+				mr.EXPECT().CheckUser("admin@example.com").Return(model.User{Role: model.RoleAdmin}, nil)
+			},
+		},
+		{
+			name: "success",
+			setup: func(mr *repository.MockUserRepository, msess *cache.MockSessionStore) {
+				mr.EXPECT().CheckUser("admin@example.com").Return(model.User{Model: gorm.Model{ID: 2}, Role: model.RoleCandidate}, nil)
+				mr.EXPECT().UpdateUserRole(uint(2), model.RoleAdmin).Return(nil)
+				msess.EXPECT().GetUserSessions(gomock.Any(), uint(2)).Return(nil, nil)
+				mr.EXPECT().GetUserByID(uint(2)).Return(model.User{Role: model.RoleAdmin}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mr := repository.NewMockUserRepository(mc)
+			msess := cache.NewMockSessionStore(mc)
+			s, _ := NewUserService(mr, authentication.NewMockAuthenticaton(mc), msess, notifier.NewMockNotifier(mc), 15*time.Minute, time.Hour,
+				time.Hour, "http://localhost/reset", 24*time.Hour, "http://localhost/verify", time.Minute)
+			tt.setup(mr, msess)
+			err := s.PromoteAdmin(context.Background(), "admin@example.com")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Service.PromoteAdmin() error = %v, wantErr %v", err, tt.wantErr)
+			}
 		})
 	}
 }
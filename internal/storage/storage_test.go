@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocalStorage_Save(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr error
+	}{
+		{
+			name:    "key outside the root",
+			key:     "../resume.pdf",
+			wantErr: ErrInvalidKey,
+		},
+		{
+			name:    "absolute key",
+			key:     "/etc/resume.pdf",
+			wantErr: ErrInvalidKey,
+		},
+		{
+			name: "success",
+			key:  "1/resume.pdf",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewLocalStorage(t.TempDir())
+			if err != nil {
+				t.Fatalf("NewLocalStorage() error = %v", err)
+			}
+			ctx := context.Background()
+			n, err := s.Save(ctx, tt.key, strings.NewReader("%PDF-1.7"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("LocalStorage.Save() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if n != 8 {
+				t.Errorf("LocalStorage.Save() wrote %d bytes, want 8", n)
+			}
+			file, err := s.Open(ctx, tt.key)
+			if err != nil {
+				t.Fatalf("LocalStorage.Open() error = %v", err)
+			}
+			data, _ := io.ReadAll(file)
+			file.Close()
+			if string(data) != "%PDF-1.7" {
+				t.Errorf("LocalStorage.Open() read %q", data)
+			}
+			err = s.Delete(ctx, tt.key)
+			if err != nil {
+				t.Errorf("LocalStorage.Delete() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSigner_Verify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	signer, _ := NewSigner("secret", time.Minute)
+	expires, signature := signer.Sign(1, now)
+	other, _ := NewSigner("other", time.Minute)
+	_, otherSignature := other.Sign(1, now)
+
+	tests := []struct {
+		name      string
+		id        uint
+		expires   int64
+		signature string
+		now       time.Time
+		want      bool
+	}{
+		{
+			name:      "valid link",
+			id:        1,
+			expires:   expires.Unix(),
+			signature: signature,
+			now:       now,
+			want:      true,
+		},
+		{
+			name:      "expired link",
+			id:        1,
+			expires:   expires.Unix(),
+			signature: signature,
+			now:       now.Add(2 * time.Minute),
+		},
+		{
+			name:      "other document",
+			id:        2,
+			expires:   expires.Unix(),
+			signature: signature,
+			now:       now,
+		},
+		{
+			name:      "expiry pushed back",
+			id:        1,
+			expires:   expires.Add(time.Hour).Unix(),
+			signature: signature,
+			now:       now,
+		},
+		{
+			name:      "signed with another secret",
+			id:        1,
+			expires:   expires.Unix(),
+			signature: otherSignature,
+			now:       now,
+		},
+		{
+			name:      "malformed signature",
+			id:        1,
+			expires:   expires.Unix(),
+			signature: "zz",
+			now:       now,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := signer.Verify(tt.id, tt.expires, tt.signature, tt.now); got != tt.want {
+				t.Errorf("Signer.Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package textextract
+
+import (
+	"errors"
+	"io"
+	"job-portal-api/internal/model"
+)
+
+// ErrUnsupportedType is returned for content types text cannot be extracted from
+var ErrUnsupportedType = errors.New("unsupported content type")
+
+// maxExtractSize bounds how much is read or decompressed from a single document
+const maxExtractSize = 20 << 20
+
+//go:generate mockgen -source=extract.go -destination=extract_mock.go -package=textextract
+type Extractor interface {
+	Extract(contentType string, r io.Reader) (string, error)
+}
+
+// TextExtractor pulls the plain text out of the documents candidates upload, only the text
+// is kept so the layout and styling are lost
+type TextExtractor struct{}
+
+func NewExtractor() Extractor {
+	return &TextExtractor{}
+}
+
+func (e *TextExtractor) Extract(contentType string, r io.Reader) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxExtractSize))
+	if err != nil {
+		return "", err
+	}
+
+	switch contentType {
+	case model.ContentTypePDF:
+		return extractPDF(data)
+	case model.ContentTypeDOCX:
+		return extractDOCX(data)
+	}
+
+	return "", ErrUnsupportedType
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: extract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=extract.go -destination=extract_mock.go -package=textextract
+//
+// Package textextract is a generated GoMock package.
+package textextract
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExtractor is a mock of Extractor interface.
+type MockExtractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockExtractorMockRecorder
+}
+
+// MockExtractorMockRecorder is the mock recorder for MockExtractor.
+type MockExtractorMockRecorder struct {
+	mock *MockExtractor
+}
+
+// NewMockExtractor creates a new mock instance.
+func NewMockExtractor(ctrl *gomock.Controller) *MockExtractor {
+	mock := &MockExtractor{ctrl: ctrl}
+	mock.recorder = &MockExtractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExtractor) EXPECT() *MockExtractorMockRecorder {
+	return m.recorder
+}
+
+// Extract mocks base method.
+func (m *MockExtractor) Extract(contentType string, r io.Reader) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Extract", contentType, r)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Extract indicates an expected call of Extract.
+func (mr *MockExtractorMockRecorder) Extract(contentType, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Extract", reflect.TypeOf((*MockExtractor)(nil).Extract), contentType, r)
+}
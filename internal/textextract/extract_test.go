@@ -0,0 +1,128 @@
+package textextract
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"job-portal-api/internal/model"
+	"strings"
+	"testing"
+)
+
+func docx(t *testing.T, document string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, err := w.Create("word/document.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write([]byte(document))
+	w.Close()
+	return buf.Bytes()
+}
+
+func pdf(content string, compress bool) []byte {
+	return pdfStreams(compress, content)
+}
+
+// pdfStreams puts every content in a stream object of its own
+func pdfStreams(compress bool, contents ...string) []byte {
+	var doc bytes.Buffer
+	doc.WriteString("%PDF-1.4\n")
+	for i, content := range contents {
+		stream := []byte(content)
+		filter := ""
+		if compress {
+			var buf bytes.Buffer
+			w := zlib.NewWriter(&buf)
+			w.Write(stream)
+			w.Close()
+			stream = buf.Bytes()
+			filter = " /Filter /FlateDecode"
+		}
+		fmt.Fprintf(&doc, "%d 0 obj\n<< /Length 10%s >>\nstream\n%s\nendstream\nendobj\n", i+4, filter, stream)
+	}
+	doc.WriteString("%%EOF")
+	return doc.Bytes()
+}
+
+// repeatedStreams returns n copies of the content followed by the last one
+func repeatedStreams(content string, n int, last string) []string {
+	contents := make([]string, 0, n+1)
+	for i := 0; i < n; i++ {
+		contents = append(contents, content)
+	}
+	return append(contents, last)
+}
+
+func TestTextExtractor_Extract(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		data        []byte
+		want        []string
+		wantErr     error
+	}{
+		{
+			name:        "docx paragraphs",
+			contentType: model.ContentTypeDOCX,
+			data: docx(t, `<w:document xmlns:w="w"><w:body><w:p><w:r><w:t>Skills:</w:t></w:r><w:r><w:tab/><w:t>Go, Java</w:t></w:r></w:p>`+
+				`<w:p><w:r><w:t>B.Tech</w:t></w:r></w:p></w:body></w:document>`),
+			want: []string{"Skills: Go, Java\n", "B.Tech\n"},
+		},
+		{
+			name:        "pdf text operators",
+			contentType: model.ContentTypePDF,
+			data:        pdf(`BT /F1 12 Tf 72 712 Td (Skills: Go) Tj [(Ja)20(va)-250(Python)] TJ (a \(b\)) Tj ET`, false),
+			want:        []string{"Skills: Go", "Java Python", "a (b)"},
+		},
+		{
+			name:        "compressed pdf stream",
+			contentType: model.ContentTypePDF,
+			data:        pdf(`BT <4B756265726E65746573> Tj ET`, true),
+			want:        []string{"Kubernetes"},
+		},
+		{
+			name:        "decompressed size is shared by the streams",
+			contentType: model.ContentTypePDF,
+			data:        pdfStreams(true, "BT (shown) Tj ET"+strings.Repeat(" ", maxExtractSize), "BT (hidden) Tj ET"),
+			want:        []string{"shown"},
+		},
+		{
+			name:        "streams past the limit are not read",
+			contentType: model.ContentTypePDF,
+			data:        pdfStreams(false, repeatedStreams("BT (shown) Tj ET", maxPDFStreams, "BT (hidden) Tj ET")...),
+			want:        []string{"shown"},
+		},
+		{
+			name:        "strings outside text objects",
+			contentType: model.ContentTypePDF,
+			data:        pdf(`(hidden) Tj BT (shown) Tj ET`, false),
+			want:        []string{"shown"},
+		},
+		{
+			name:        "unsupported type",
+			contentType: "text/plain",
+			data:        []byte("Go"),
+			wantErr:     ErrUnsupportedType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewExtractor().Extract(tt.contentType, bytes.NewReader(tt.data))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TextExtractor.Extract() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, v := range tt.want {
+				if !strings.Contains(got, v) {
+					t.Errorf("TextExtractor.Extract() = %q, want it to contain %q", got, v)
+				}
+			}
+			if strings.Contains(got, "hidden") {
+				t.Errorf("TextExtractor.Extract() = %q, read a string outside a text object", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package textextract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxPDFStreams bounds how many streams of a document are read, a resume needs only a few
+const maxPDFStreams = 1024
+
+// extractPDF collects the strings shown by the text operators of every content stream, it does not
+// understand font encodings so text written with embedded CID fonts is not found
+func extractPDF(data []byte) (string, error) {
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return "", errors.New("not a pdf document")
+	}
+
+	var text strings.Builder
+	rest := data
+
+	//the streams share one decompression budget so many small streams cannot add up to a large output
+	budget := int64(maxExtractSize)
+	streams := 0
+
+	for {
+		start := bytes.Index(rest, []byte("stream"))
+		if start < 0 {
+			break
+		}
+		//endstream also contains the keyword
+		if start >= 3 && string(rest[start-3:start]) == "end" {
+			rest = rest[start+len("stream"):]
+			continue
+		}
+
+		dictionary := rest[:start]
+		if obj := bytes.LastIndex(dictionary, []byte("obj")); obj >= 0 {
+			dictionary = dictionary[obj:]
+		}
+
+		body := rest[start+len("stream"):]
+		body = bytes.TrimPrefix(body, []byte("\r"))
+		body = bytes.TrimPrefix(body, []byte("\n"))
+
+		end := bytes.Index(body, []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		content := body[:end]
+		rest = body[end+len("endstream"):]
+
+		streams++
+		if streams > maxPDFStreams {
+			break
+		}
+
+		if bytes.Contains(dictionary, []byte("/FlateDecode")) {
+			if budget <= 0 {
+				break
+			}
+			content = inflate(content, budget)
+			budget -= int64(len(content))
+		}
+		contentText(content, &text)
+	}
+
+	return text.String(), nil
+}
+
+// inflate decompresses up to limit bytes of a stream, whatever could be read is kept when the stream is damaged
+func inflate(content []byte, limit int64) []byte {
+	reader, err := zlib.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil
+	}
+	defer reader.Close()
+
+	out, _ := io.ReadAll(io.LimitReader(reader, limit))
+	return out
+}
+
+// contentText writes the strings shown between BT and ET, the parts of a TJ array are joined and
+// a large negative adjustment inside it is read as the space between two words
+func contentText(content []byte, text *strings.Builder) {
+	var pending strings.Builder
+	inText := false
+	inArray := false
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == '(':
+			s, next := literalString(content, i)
+			if inText {
+				pending.WriteString(s)
+			}
+			i = next
+		case c == '<' && i+1 < len(content) && content[i+1] == '<':
+			i += 2
+		case c == '<':
+			s, next := hexString(content, i)
+			if inText {
+				pending.WriteString(s)
+			}
+			i = next
+		case c == '[':
+			inArray = true
+			i++
+		case c == ']':
+			inArray = false
+			i++
+		case c == '-' || c == '.' || (c >= '0' && c <= '9'):
+			j := i + 1
+			for j < len(content) && (content[j] == '.' || (content[j] >= '0' && content[j] <= '9')) {
+				j++
+			}
+			if inArray && inText {
+				n, err := strconv.ParseFloat(string(content[i:j]), 64)
+				if err == nil && n < -100 {
+					pending.WriteString(" ")
+				}
+			}
+			i = j
+		case isLetter(c) || c == '\'' || c == '"' || c == '*':
+			j := i + 1
+			for j < len(content) && (isLetter(content[j]) || content[j] == '*') {
+				j++
+			}
+			switch string(content[i:j]) {
+			case "BT":
+				inText = true
+			case "ET":
+				inText = false
+				text.WriteString("\n")
+			}
+			if pending.Len() > 0 {
+				text.WriteString(pending.String())
+				text.WriteString(" ")
+				pending.Reset()
+			}
+			i = j
+		default:
+			i++
+		}
+	}
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// literalString reads the string opening at content[start] and returns it with the index after it
+func literalString(content []byte, start int) (string, int) {
+	var s strings.Builder
+	depth := 0
+
+	for i := start; i < len(content); i++ {
+		c := content[i]
+		switch c {
+		case '(':
+			if depth > 0 {
+				s.WriteByte(c)
+			}
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return s.String(), i + 1
+			}
+			s.WriteByte(c)
+		case '\\':
+			i++
+			if i >= len(content) {
+				return s.String(), i
+			}
+			switch e := content[i]; e {
+			case 'n', 'r':
+				s.WriteByte('\n')
+			case 't':
+				s.WriteByte(' ')
+			case 'b', 'f':
+			case '\r', '\n':
+				//a backslash at the end of a line continues the string on the next line
+			default:
+				if e >= '0' && e <= '7' {
+					j := i
+					for j < len(content) && j < i+3 && content[j] >= '0' && content[j] <= '7' {
+						j++
+					}
+					n, _ := strconv.ParseUint(string(content[i:j]), 8, 8)
+					s.WriteByte(byte(n))
+					i = j - 1
+				} else {
+					s.WriteByte(e)
+				}
+			}
+		default:
+			s.WriteByte(c)
+		}
+	}
+
+	return s.String(), len(content)
+}
+
+// hexString reads the hex string opening at content[start], strings which do not decode to
+// printable text belong to fonts this reader does not understand and are dropped
+func hexString(content []byte, start int) (string, int) {
+	end := bytes.IndexByte(content[start:], '>')
+	if end < 0 {
+		return "", len(content)
+	}
+
+	var digits []byte
+	for _, c := range content[start+1 : start+end] {
+		if (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') {
+			digits = append(digits, c)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	decoded := make([]byte, 0, len(digits)/2)
+	for i := 0; i < len(digits); i += 2 {
+		n, _ := strconv.ParseUint(string(digits[i:i+2]), 16, 8)
+		if n < 0x20 || n > 0x7e {
+			return "", start + end + 1
+		}
+		decoded = append(decoded, byte(n))
+	}
+
+	return string(decoded), start + end + 1
+}